@@ -12,10 +12,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var repoFlag string
+var (
+	repoFlag             string
+	addProjectFlag       string
+	addFieldFlag         []string
+	addSearchFlag        string
+	addReplaceParentFlag bool
+	addYesFlag           bool
+)
 
 var addCmd = &cobra.Command{
-	Use:   "add <parent-issue> <sub-issue>",
+	Use:   "add [<parent-issue> <sub-issue>]",
 	Short: "Add an existing issue as a sub-issue to a parent issue",
 	Long: `Link an existing issue to a parent issue using GitHub's issue hierarchy feature.
 
@@ -27,8 +34,28 @@ Examples:
   gh sub-issues add https://github.com/owner/repo/issues/123 456
   
   # Cross-repository linking
-  gh sub-issues add 123 456 --repo owner/repo`,
-	Args: cobra.ExactArgs(2),
+  gh sub-issues add 123 456 --repo owner/repo
+  
+  # Also add the sub-issue to the parent's project 4 with a status and estimate
+  gh sub-issues add 123 456 --project 4 --field Status=Todo --field Estimate=3
+  
+  # Add the sub-issue to the parent's only project
+  gh sub-issues add 123 456 --field Status=Todo
+  
+  # Link every issue matching a search to epic #123
+  gh sub-issues add 123 --search "label:area:auth milestone:v3"
+  
+  # Move matching issues from their current parents to #123
+  gh sub-issues add 123 --search "label:area:auth" --replace-parent --yes
+  
+  # Pick the parent and sub-issues interactively
+  gh sub-issues add`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if addSearchFlag != "" {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return exactArgsOrInteractive(2)(cmd, args)
+	},
 	RunE: runAdd,
 }
 
@@ -38,6 +65,11 @@ func init() {
 	
 	// Add flags
 	addCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	addCmd.Flags().StringVar(&addProjectFlag, "project", "", "Also add sub-issues to this project, which the parent must be in: {NUMBER|OWNER/NUMBER}")
+	addCmd.Flags().StringArrayVar(&addFieldFlag, "field", nil, "Project field value in NAME=VALUE format (repeatable); without --project, the parent's project is used")
+	addCmd.Flags().StringVar(&addSearchFlag, "search", "", "Add the issues matching this GitHub search query")
+	addCmd.Flags().BoolVar(&addReplaceParentFlag, "replace-parent", false, "Move issues that already have a parent instead of skipping them")
+	addCmd.Flags().BoolVarP(&addYesFlag, "yes", "y", false, "Add the issues found by --search without asking for confirmation")
 }
 
 // IssueReference represents a parsed issue reference
@@ -145,13 +177,15 @@ func getIssueNodeID(client *api.GraphQLClient, owner, repo string, number int) (
 	return response.Repository.Issue.ID, nil
 }
 
-// addSubIssue links a sub-issue to a parent issue
-func addSubIssue(client *api.GraphQLClient, parentID, subIssueID string) (int, int, error) {
+// addSubIssue links a sub-issue to a parent issue.
+// With replaceParent, a sub-issue that already has a parent is moved.
+func addSubIssue(client *api.GraphQLClient, parentID, subIssueID string, replaceParent bool) (int, int, error) {
 	mutation := `
-		mutation($parentId: ID!, $subIssueId: ID!) {
+		mutation($parentId: ID!, $subIssueId: ID!, $replaceParent: Boolean) {
 			addSubIssue(input: {
 				issueId: $parentId,
-				subIssueId: $subIssueId
+				subIssueId: $subIssueId,
+				replaceParent: $replaceParent
 			}) {
 				issue {
 					number
@@ -165,10 +199,22 @@ func addSubIssue(client *api.GraphQLClient, parentID, subIssueID string) (int, i
 		}`
 	
 	variables := map[string]interface{}{
-		"parentId":   parentID,
-		"subIssueId": subIssueID,
+		"parentId":      parentID,
+		"subIssueId":    subIssueID,
+		"replaceParent": replaceParent,
+	}
+	
+	description := fmt.Sprintf("Add %s as a sub-issue of %s", subIssueID, parentID)
+	if replaceParent {
+		description += ", replacing its parent"
+	}
+	if recordMutation("addSubIssue", variables, description, subIssueID, parentID) {
+		numbers := plannedIssueNumbers(client, parentID, subIssueID)
+		return numbers[0], numbers[1], nil
 	}
 	
+	positions := journalPositions(client, parentID, subIssueID)
+	
 	var response struct {
 		AddSubIssue struct {
 			Issue struct {
@@ -182,14 +228,101 @@ func addSubIssue(client *api.GraphQLClient, parentID, subIssueID string) (int, i
 		} `json:"addSubIssue"`
 	}
 	
-	err := client.Do(mutation, variables, &response)
-	if err != nil {
+	if err := client.Do(mutation, variables, &response); err != nil {
 		return 0, 0, fmt.Errorf("failed to add sub-issue: %w", err)
 	}
 	
+	action := journalAdd
+	if previous := positions[subIssueID].Parent; previous != nil && previous.ID != parentID {
+		action = journalMove
+	}
+	journalChange(action, positions, parentID, subIssueID)
+	
 	return response.AddSubIssue.Issue.Number, response.AddSubIssue.SubIssue.Number, nil
 }
 
+// removeSubIssue unlinks a sub-issue from its parent issue
+func removeSubIssue(client *api.GraphQLClient, parentID, subIssueID string) error {
+	mutation := `
+		mutation($parentId: ID!, $subIssueId: ID!) {
+			removeSubIssue(input: {
+				issueId: $parentId,
+				subIssueId: $subIssueId
+			}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"parentId":   parentID,
+		"subIssueId": subIssueID,
+	}
+	if recordMutation("removeSubIssue", variables,
+		fmt.Sprintf("Remove %s from the sub-issues of %s", subIssueID, parentID), subIssueID, parentID) {
+		return nil
+	}
+
+	positions := journalPositions(client, parentID, subIssueID)
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to remove sub-issue: %w", err)
+	}
+	journalChange(journalRemove, positions, parentID, subIssueID)
+
+	return nil
+}
+
+// reprioritizeSubIssue moves a sub-issue directly after afterID, or before beforeID
+func reprioritizeSubIssue(client *api.GraphQLClient, parentID, subIssueID, afterID, beforeID string) error {
+	mutation := `
+		mutation($parentId: ID!, $subIssueId: ID!, $afterId: ID, $beforeId: ID) {
+			reprioritizeSubIssue(input: {
+				issueId: $parentId,
+				subIssueId: $subIssueId,
+				afterId: $afterId,
+				beforeId: $beforeId
+			}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"parentId":   parentID,
+		"subIssueId": subIssueID,
+		"afterId":    nil,
+		"beforeId":   nil,
+	}
+	if afterID != "" {
+		variables["afterId"] = afterID
+	}
+	if beforeID != "" {
+		variables["beforeId"] = beforeID
+	}
+
+	description := fmt.Sprintf("Move %s after %s among the sub-issues of %s", subIssueID, afterID, parentID)
+	if beforeID != "" {
+		description = fmt.Sprintf("Move %s before %s among the sub-issues of %s", subIssueID, beforeID, parentID)
+	}
+	if recordMutation("reprioritizeSubIssue", variables, description, subIssueID, afterID, beforeID, parentID) {
+		return nil
+	}
+
+	positions := journalPositions(client, parentID, subIssueID)
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to reorder sub-issue: %w", err)
+	}
+	journalChange(journalReorder, positions, parentID, subIssueID)
+
+	return nil
+}
+
 // getDefaultRepo gets the repository from current directory
 func getDefaultRepo() (string, string, error) {
 	// Try to get from git remote using gh CLI
@@ -217,6 +350,23 @@ func getDefaultRepo() (string, string, error) {
 	return repo.Owner.Login, repo.Name, nil
 }
 
+// resolveRepo returns the repository from the --repo flag value or the current directory
+func resolveRepo(flag string) (string, string, error) {
+	if flag != "" {
+		parts := strings.Split(flag, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", "", fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", flag)
+		}
+		return parts[0], parts[1], nil
+	}
+
+	owner, repo, err := getDefaultRepo()
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine repository (use --repo flag): %w", err)
+	}
+	return owner, repo, nil
+}
+
 // runAdd is the main command logic
 func runAdd(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
@@ -241,28 +391,53 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 	
-	// Parse parent and sub-issue references
-	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	// Create GraphQL client using default options
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
 	if err != nil {
-		return fmt.Errorf("invalid parent issue: %w", err)
+		return fmt.Errorf("failed to create GitHub client: %w", err)
 	}
 	
-	subRef, err := parseIssueReference(args[1], defaultOwner, defaultRepo)
-	if err != nil {
-		return fmt.Errorf("invalid sub-issue: %w", err)
-	}
+	var parentRef *IssueReference
+	var subRefs []*IssueReference
 	
-	// Check for circular dependency
-	if parentRef.Owner == subRef.Owner && 
-	   parentRef.Repo == subRef.Repo && 
-	   parentRef.Number == subRef.Number {
-		return fmt.Errorf("cannot add issue as its own sub-issue")
+	if len(args) == 0 {
+		// No arguments in an interactive terminal: pick the issues
+		parentRef, subRefs, err = pickAddIssues(client, defaultOwner, defaultRepo)
+		if err != nil {
+			return err
+		}
+	} else if addSearchFlag != "" {
+		// Find the sub-issues with a GitHub search
+		parentRef, err = parseIssueReference(args[0], defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+		
+		subRefs, err = searchSubIssues(cmd, client, parentRef)
+		if err != nil || len(subRefs) == 0 {
+			return err
+		}
+	} else {
+		// Parse parent and sub-issue references
+		parentRef, err = parseIssueReference(args[0], defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+		
+		subRef, err := parseIssueReference(args[1], defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid sub-issue: %w", err)
+		}
+		subRefs = []*IssueReference{subRef}
 	}
 	
-	// Create GraphQL client using default options
-	client, err := api.NewGraphQLClient(api.ClientOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to create GitHub client: %w", err)
+	// Check for circular dependency
+	for _, subRef := range subRefs {
+		if parentRef.Owner == subRef.Owner && 
+		   parentRef.Repo == subRef.Repo && 
+		   parentRef.Number == subRef.Number {
+			return fmt.Errorf("cannot add issue as its own sub-issue")
+		}
 	}
 	
 	// Get node IDs for both issues
@@ -283,6 +458,55 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	
+	// Resolve the parent's project and its field values before linking anything
+	var project *Project
+	var updates []ProjectFieldUpdate
+	if addProjectFlag != "" || len(addFieldFlag) > 0 {
+		project, updates, err = resolveParentProject(client, addProjectFlag, parentRef, parentID, addFieldFlag)
+		if err != nil {
+			return err
+		}
+	}
+	
+	// Hierarchy rules of the parent's repository
+	config, err := loadRepoConfig(parentRef.Owner, parentRef.Repo)
+	if err != nil {
+		return err
+	}
+	
+	// A single sub-issue fails with its error; with several, the others are still added
+	if len(subRefs) == 1 {
+		return addSubIssueRef(cmd, client, config, parentRef, parentID, subRefs[0], project, updates)
+	}
+	failed := 0
+	for _, subRef := range subRefs {
+		if err := addSubIssueRef(cmd, client, config, parentRef, parentID, subRef, project, updates); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "✗ #%d: %v\n", subRef.Number, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to add %d of %d issues", failed, len(subRefs))
+	}
+	
+	_ = ctx // Use context if needed in future
+	return nil
+}
+
+// addSubIssueRef links a sub-issue and adds it to the project, if any
+func addSubIssueRef(cmd *cobra.Command, client *api.GraphQLClient, config *RepoConfig, parentRef *IssueReference, parentID string, subRef *IssueReference, project *Project, updates []ProjectFieldUpdate) error {
+	subID, err := linkSubIssue(cmd, client, config, parentRef, parentID, subRef, addReplaceParentFlag)
+	if err != nil {
+		return err
+	}
+	if project != nil {
+		return addToProject(cmd, client, project, updates, subID, subRef.Number)
+	}
+	return nil
+}
+
+// linkSubIssue links a single sub-issue to an already resolved parent issue
+func linkSubIssue(cmd *cobra.Command, client *api.GraphQLClient, config *RepoConfig, parentRef *IssueReference, parentID string, subRef *IssueReference, replaceParent bool) (string, error) {
 	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue #%d from %s/%s...\n", 
 		subRef.Number, subRef.Owner, subRef.Repo)
 	
@@ -290,30 +514,33 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		// Check if it's a permission error
 		if strings.Contains(err.Error(), "permission") || strings.Contains(err.Error(), "403") {
-			return fmt.Errorf("insufficient permissions to access %s/%s", 
+			return "", fmt.Errorf("insufficient permissions to access %s/%s", 
 				subRef.Owner, subRef.Repo)
 		}
-		return err
+		return "", err
+	}
+	
+	// Check the issue type rules
+	if err := validateLink(client, config, parentID, subID); err != nil {
+		return "", fmt.Errorf("cannot add issue #%d to #%d: %w", subRef.Number, parentRef.Number, err)
 	}
 	
 	// Link the issues
 	fmt.Fprintf(cmd.OutOrStderr(), "Linking issues...\n")
-	parentNum, subNum, err := addSubIssue(client, parentID, subID)
+	parentNum, subNum, err := addSubIssue(client, parentID, subID, replaceParent)
 	if err != nil {
 		// Check for specific error cases
 		if strings.Contains(err.Error(), "permission") || strings.Contains(err.Error(), "403") {
-			return fmt.Errorf("insufficient permissions to modify issues in this repository")
+			return "", fmt.Errorf("insufficient permissions to modify issues in this repository")
 		}
 		if strings.Contains(err.Error(), "already") {
-			return fmt.Errorf("issue #%d is already a sub-issue of #%d", 
+			return "", fmt.Errorf("issue #%d is already a sub-issue of #%d", 
 				subRef.Number, parentRef.Number)
 		}
-		return err
+		return "", err
 	}
 	
 	// Success message
 	fmt.Fprintf(cmd.OutOrStdout(), "✓ Added issue #%d as a sub-issue of #%d\n", subNum, parentNum)
-	
-	_ = ctx // Use context if needed in future
-	return nil
+	return subID, nil
 }
\ No newline at end of file
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// maxSubIssues is the number of sub-issues GitHub allows below one parent issue
+const maxSubIssues = 100
+
+// maxSearchResults is the number of results the GitHub search API returns at most
+const maxSearchResults = 1000
+
+// SearchIssue is an issue found by a search, with its current parent
+type SearchIssue struct {
+	ID         string
+	Repository string
+	Number     int
+	Title      string
+	URL        string
+	Parent     *IssueLink
+}
+
+// searchQuery scopes a search query to issues in a repository, unless it names its own scope
+func searchQuery(query, owner, repo string) string {
+	terms := strings.Fields(query)
+	hasType, hasScope := false, false
+	for _, word := range terms {
+		lower := strings.ToLower(word)
+		if lower == "is:issue" || lower == "type:issue" {
+			hasType = true
+		}
+		for _, qualifier := range []string{"repo:", "org:", "user:", "owner:"} {
+			if strings.HasPrefix(lower, qualifier) {
+				hasScope = true
+			}
+		}
+	}
+
+	if !hasScope {
+		terms = append([]string{fmt.Sprintf("repo:%s/%s", owner, repo)}, terms...)
+	}
+	if !hasType {
+		terms = append([]string{"is:issue"}, terms...)
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchIssues runs a GitHub issue search
+func searchIssues(client *api.GraphQLClient, query string) ([]SearchIssue, error) {
+	gql := `
+		query($query: String!, $after: String) {
+			search(query: $query, type: ISSUE, first: 100, after: $after) {
+				nodes {
+					... on Issue {
+						id
+						number
+						title
+						url
+						repository {
+							nameWithOwner
+						}
+						parent {` + linkedIssueFields + `
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"query": query,
+		"after": nil,
+	}
+
+	var issues []SearchIssue
+	for len(issues) < maxSearchResults {
+		var response struct {
+			Search struct {
+				Nodes []struct {
+					ID         string `json:"id"`
+					Number     int    `json:"number"`
+					Title      string `json:"title"`
+					URL        string `json:"url"`
+					Repository struct {
+						NameWithOwner string `json:"nameWithOwner"`
+					} `json:"repository"`
+					Parent *linkedIssue `json:"parent"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"search"`
+		}
+
+		if err := client.Do(gql, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		for _, node := range response.Search.Nodes {
+			if node.ID == "" {
+				continue // Skip pull requests
+			}
+			issue := SearchIssue{
+				ID:         node.ID,
+				Repository: node.Repository.NameWithOwner,
+				Number:     node.Number,
+				Title:      node.Title,
+				URL:        node.URL,
+			}
+			if node.Parent != nil {
+				parent := node.Parent.toLink()
+				issue.Parent = &parent
+			}
+			issues = append(issues, issue)
+		}
+
+		if !response.Search.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Search.PageInfo.EndCursor
+	}
+
+	return issues, nil
+}
+
+// searchLinkPlan sorts search results into the issues to link and the ones skipped
+type searchLinkPlan struct {
+	Link      []SearchIssue
+	Linked    []SearchIssue
+	Parented  []SearchIssue
+	OverLimit []SearchIssue
+}
+
+// planSearchLinks decides which search results to link below a parent that has
+// existing sub-issues, keeping the parent within the sub-issue limit
+func planSearchLinks(results []SearchIssue, parentID string, existing map[string]bool, replaceParent bool) *searchLinkPlan {
+	plan := &searchLinkPlan{}
+	capacity := maxSubIssues - len(existing)
+
+	for _, issue := range results {
+		switch {
+		case issue.ID == parentID:
+			continue
+		case existing[issue.ID]:
+			plan.Linked = append(plan.Linked, issue)
+		case issue.Parent != nil && !replaceParent:
+			plan.Parented = append(plan.Parented, issue)
+		case len(plan.Link) >= capacity:
+			plan.OverLimit = append(plan.OverLimit, issue)
+		default:
+			plan.Link = append(plan.Link, issue)
+		}
+	}
+	return plan
+}
+
+// formatSearchLinkPlan describes what linking the search results will do
+func formatSearchLinkPlan(plan *searchLinkPlan, parentRef *IssueReference) string {
+	var output strings.Builder
+	repository := parentRef.Owner + "/" + parentRef.Repo
+	ref := func(issue SearchIssue) string {
+		return linkRef(IssueLink{Repository: issue.Repository, Number: issue.Number}, repository)
+	}
+
+	for _, issue := range plan.Link {
+		line := fmt.Sprintf("  + %s %s", ref(issue), issue.Title)
+		if issue.Parent != nil {
+			line += fmt.Sprintf(" (moved from %s)", linkRef(*issue.Parent, repository))
+		}
+		output.WriteString(line + "\n")
+	}
+	for _, issue := range plan.Linked {
+		output.WriteString(fmt.Sprintf("  = %s %s (already a sub-issue)\n", ref(issue), issue.Title))
+	}
+	for _, issue := range plan.Parented {
+		output.WriteString(fmt.Sprintf("  - %s %s (sub-issue of %s, use --replace-parent to move)\n",
+			ref(issue), issue.Title, linkRef(*issue.Parent, repository)))
+	}
+	for _, issue := range plan.OverLimit {
+		output.WriteString(fmt.Sprintf("  - %s %s (over the limit of %d sub-issues)\n", ref(issue), issue.Title, maxSubIssues))
+	}
+	return output.String()
+}
+
+// searchSubIssues finds the issues to add with --search and confirms them with the user
+func searchSubIssues(cmd *cobra.Command, client *api.GraphQLClient, parentRef *IssueReference) ([]*IssueReference, error) {
+	query := searchQuery(addSearchFlag, parentRef.Owner, parentRef.Repo)
+	fmt.Fprintf(cmd.OutOrStderr(), "Searching for %q...\n", query)
+	results, err := searchIssues(client, query)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := getIssueNodeID(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := getSubIssueIDs(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planSearchLinks(results, parentID, existing, addReplaceParentFlag)
+	fmt.Fprintf(cmd.OutOrStdout(), "Found %d issues:\n%s", len(results), formatSearchLinkPlan(plan, parentRef))
+	if len(plan.Link) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Nothing to add to #%d\n", parentRef.Number)
+		return nil, nil
+	}
+
+	if !addYesFlag && dryRun == nil {
+		if !term.IsTerminal(os.Stdin) {
+			return nil, fmt.Errorf("refusing to add without confirmation (use --yes)")
+		}
+		ok, err := confirm(cmd, fmt.Sprintf("Add %d issues to #%d?", len(plan.Link), parentRef.Number))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("add cancelled")
+		}
+	}
+
+	var subRefs []*IssueReference
+	for _, issue := range plan.Link {
+		owner, repo := splitRepository(issue.Repository)
+		subRefs = append(subRefs, &IssueReference{Owner: owner, Repo: repo, Number: issue.Number})
+	}
+	return subRefs, nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSearchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"label:area:auth milestone:v3", "is:issue repo:owner/repo label:area:auth milestone:v3"},
+		{"is:issue label:bug", "repo:owner/repo is:issue label:bug"},
+		{"org:acme label:bug", "is:issue org:acme label:bug"},
+		{"repo:other/repo is:issue  is:open", "repo:other/repo is:issue is:open"},
+	}
+
+	for _, tt := range tests {
+		if got := searchQuery(tt.query, "owner", "repo"); got != tt.want {
+			t.Errorf("searchQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestPlanSearchLinks(t *testing.T) {
+	other := &IssueLink{Repository: "owner/repo", Number: 9}
+	results := []SearchIssue{
+		{ID: "P", Repository: "owner/repo", Number: 1, Title: "Parent"},
+		{ID: "A", Repository: "owner/repo", Number: 2, Title: "Linked"},
+		{ID: "B", Repository: "owner/repo", Number: 3, Title: "Free"},
+		{ID: "C", Repository: "owner/repo", Number: 4, Title: "Parented", Parent: other},
+		{ID: "D", Repository: "owner/other", Number: 5, Title: "Free elsewhere"},
+	}
+	existing := map[string]bool{"A": true}
+
+	plan := planSearchLinks(results, "P", existing, false)
+	if len(plan.Link) != 2 || plan.Link[0].ID != "B" || plan.Link[1].ID != "D" {
+		t.Errorf("Link = %v", plan.Link)
+	}
+	if len(plan.Linked) != 1 || len(plan.Parented) != 1 || len(plan.OverLimit) != 0 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+
+	want := "  + #3 Free\n" +
+		"  + owner/other#5 Free elsewhere\n" +
+		"  = #2 Linked (already a sub-issue)\n" +
+		"  - #4 Parented (sub-issue of #9, use --replace-parent to move)\n"
+	parentRef := &IssueReference{Owner: "owner", Repo: "repo", Number: 1}
+	if got := formatSearchLinkPlan(plan, parentRef); got != want {
+		t.Errorf("formatSearchLinkPlan() =\n%s\nwant:\n%s", got, want)
+	}
+
+	plan = planSearchLinks(results, "P", existing, true)
+	if len(plan.Link) != 3 || plan.Link[1].ID != "C" || len(plan.Parented) != 0 {
+		t.Errorf("replace parent: Link = %v", plan.Link)
+	}
+	if got := formatSearchLinkPlan(plan, parentRef); got != "  + #3 Free\n  + #4 Parented (moved from #9)\n  + owner/other#5 Free elsewhere\n  = #2 Linked (already a sub-issue)\n" {
+		t.Errorf("replace parent: formatSearchLinkPlan() = %q", got)
+	}
+}
+
+func TestPlanSearchLinksLimit(t *testing.T) {
+	existing := make(map[string]bool)
+	for i := 0; i < maxSubIssues-2; i++ {
+		existing[fmt.Sprintf("E%d", i)] = true
+	}
+	var results []SearchIssue
+	for i := 0; i < 5; i++ {
+		results = append(results, SearchIssue{ID: fmt.Sprintf("S%d", i), Number: 10 + i})
+	}
+
+	plan := planSearchLinks(results, "P", existing, false)
+	if len(plan.Link) != 2 || len(plan.OverLimit) != 3 {
+		t.Errorf("got %d to link and %d over the limit, want 2 and 3", len(plan.Link), len(plan.OverLimit))
+	}
+}
@@ -0,0 +1,723 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	applyFileFlag string
+	applyRepoFlag string
+	applyYesFlag  bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan -f <file>",
+	Short: "Preview the changes a hierarchy plan would make",
+	Long: `Compare a YAML hierarchy plan with the current state of the repository and
+print the issues that would be created, linked and reordered by apply.
+
+Examples:
+  gh sub-issues plan -f plan.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runPlan,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file>",
+	Short: "Create and link issues from a YAML hierarchy plan",
+	Long: `Create missing issues from a YAML hierarchy plan, link them as sub-issues and
+reorder them to match the plan.
+
+Each planned issue has a stable key which is stored as a hidden marker in the
+issue body, so applying the same plan again only performs the missing changes.
+Existing issues can be included with a ref instead of a key.
+
+Plan file format:
+  repo: owner/repo            # optional, defaults to --repo or the current repository
+  issues:
+    - key: auth
+      title: Authentication
+      body: Implement the complete auth system
+      labels: [epic]
+      assignees: [alice]
+      milestone: v1.0
+      children:
+        - key: auth-login
+          title: Login endpoint
+        - ref: "#95"          # existing issue, number, owner/repo#number or URL
+
+Examples:
+  # Preview and apply a plan
+  gh sub-issues apply -f plan.yaml
+
+  # Apply without confirmation (e.g. in CI)
+  gh sub-issues apply -f plan.yaml --yes`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func init() {
+	// Add commands to root
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+
+	// Add flags
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVarP(&applyFileFlag, "file", "f", "", "Path to the YAML plan file (required)")
+		c.Flags().StringVarP(&applyRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+		c.MarkFlagRequired("file")
+	}
+	applyCmd.Flags().BoolVarP(&applyYesFlag, "yes", "y", false, "Apply without asking for confirmation")
+}
+
+// PlanFile represents a YAML hierarchy plan
+type PlanFile struct {
+	Repo   string     `yaml:"repo"`
+	Issues []PlanNode `yaml:"issues"`
+}
+
+// PlanNode represents a planned issue and its children
+type PlanNode struct {
+	Key       string     `yaml:"key"`
+	Ref       string     `yaml:"ref"`
+	Title     string     `yaml:"title"`
+	Body      string     `yaml:"body"`
+	Labels    []string   `yaml:"labels"`
+	Assignees []string   `yaml:"assignees"`
+	Milestone string     `yaml:"milestone"`
+	Children  []PlanNode `yaml:"children"`
+}
+
+// PlannedIssue represents an existing issue matched to a plan node
+type PlannedIssue struct {
+	ID     string
+	Number int
+
+	// Parent is the current parent of the issue, if any
+	Parent *PlannedIssue
+}
+
+// plannedIssueFields are the fields fetched for an existing issue of a plan
+const plannedIssueFields = `
+	id
+	number
+	parent {
+		id
+		number
+	}`
+
+// plannedIssue is the GraphQL shape of plannedIssueFields
+type plannedIssue struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Parent *struct {
+		ID     string `json:"id"`
+		Number int    `json:"number"`
+	} `json:"parent"`
+}
+
+// toPlanned converts the GraphQL shape into a planned issue
+func (i plannedIssue) toPlanned() *PlannedIssue {
+	issue := &PlannedIssue{ID: i.ID, Number: i.Number}
+	if i.Parent != nil {
+		issue.Parent = &PlannedIssue{ID: i.Parent.ID, Number: i.Parent.Number}
+	}
+	return issue
+}
+
+// PlanStep represents a single change computed from a plan
+type PlanStep struct {
+	Action   string
+	Node     *PlanNode
+	Parent   *PlanNode
+	Children []*PlanNode
+}
+
+// PlanState holds what already exists for a plan
+type PlanState struct {
+	Issues   map[*PlanNode]*PlannedIssue
+	Children map[string][]string
+}
+
+var planKeyPattern = regexp.MustCompile(`<!-- sub-issues:key=([^ ]+) -->`)
+
+// planKeyMarker returns the hidden body marker for a plan key
+func planKeyMarker(key string) string {
+	return fmt.Sprintf("<!-- sub-issues:key=%s -->", key)
+}
+
+// extractPlanKey returns the plan key stored in an issue body
+func extractPlanKey(body string) string {
+	match := planKeyPattern.FindStringSubmatch(body)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// parsePlanFile parses and validates a YAML hierarchy plan
+func parsePlanFile(data []byte) (*PlanFile, error) {
+	var plan PlanFile
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if len(plan.Issues) == 0 {
+		return nil, fmt.Errorf("plan contains no issues")
+	}
+
+	keys := make(map[string]bool)
+	var validate func(nodes []PlanNode) error
+	validate = func(nodes []PlanNode) error {
+		for _, node := range nodes {
+			switch {
+			case node.Ref != "" && node.Key != "":
+				return fmt.Errorf("issue %q: key and ref are mutually exclusive", node.Key)
+			case node.Ref == "" && node.Key == "":
+				return fmt.Errorf("issue %q: either key or ref is required", node.Title)
+			case node.Key != "" && node.Title == "":
+				return fmt.Errorf("issue %q: title is required", node.Key)
+			case node.Key != "" && strings.ContainsAny(node.Key, " \t\n"):
+				return fmt.Errorf("issue %q: key must not contain whitespace", node.Key)
+			}
+
+			if node.Key != "" {
+				if keys[node.Key] {
+					return fmt.Errorf("duplicate key: %s", node.Key)
+				}
+				keys[node.Key] = true
+			}
+
+			if err := validate(node.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := validate(plan.Issues); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// buildPlanSteps computes the changes needed to make the repository match the plan
+func buildPlanSteps(nodes []PlanNode, state *PlanState) []PlanStep {
+	var steps []PlanStep
+
+	var walk func(nodes []PlanNode, parent *PlanNode)
+	walk = func(nodes []PlanNode, parent *PlanNode) {
+		for i := range nodes {
+			node := &nodes[i]
+			existing := state.Issues[node]
+
+			if existing == nil {
+				steps = append(steps, PlanStep{Action: "create", Node: node})
+			} else {
+				steps = append(steps, PlanStep{Action: "exists", Node: node})
+			}
+
+			// An existing issue with another parent has to be moved
+			if parent != nil && !isPlannedChild(state, parent, existing) {
+				action := "link"
+				if existing != nil && existing.Parent != nil {
+					action = "move"
+				}
+				steps = append(steps, PlanStep{Action: action, Node: node, Parent: parent})
+			}
+
+			walk(node.Children, node)
+		}
+
+		if parent != nil && len(nodes) > 1 && !isPlannedOrder(state, parent, nodes) {
+			children := make([]*PlanNode, len(nodes))
+			for i := range nodes {
+				children[i] = &nodes[i]
+			}
+			steps = append(steps, PlanStep{Action: "reorder", Parent: parent, Children: children})
+		}
+	}
+
+	walk(nodes, nil)
+	return steps
+}
+
+// isPlannedChild reports whether an existing issue is already a sub-issue of the planned parent
+func isPlannedChild(state *PlanState, parent *PlanNode, child *PlannedIssue) bool {
+	p := state.Issues[parent]
+	if p == nil || child == nil {
+		return false
+	}
+	if child.Parent != nil && child.Parent.ID == p.ID {
+		return true
+	}
+	for _, id := range state.Children[p.ID] {
+		if id == child.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// isPlannedOrder reports whether the planned children already appear in the planned order
+func isPlannedOrder(state *PlanState, parent *PlanNode, nodes []PlanNode) bool {
+	p := state.Issues[parent]
+	if p == nil {
+		return false
+	}
+
+	wanted := make(map[string]bool)
+	var desired []string
+	for i := range nodes {
+		child := state.Issues[&nodes[i]]
+		if child == nil {
+			return false
+		}
+		wanted[child.ID] = true
+		desired = append(desired, child.ID)
+	}
+
+	var current []string
+	for _, id := range state.Children[p.ID] {
+		if wanted[id] {
+			current = append(current, id)
+		}
+	}
+
+	if len(current) != len(desired) {
+		return false
+	}
+	for i := range desired {
+		if current[i] != desired[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// planNodeLabel formats a plan node for display
+func planNodeLabel(node *PlanNode, state *PlanState) string {
+	if existing := state.Issues[node]; existing != nil && existing.Number > 0 {
+		if node.Title != "" {
+			return fmt.Sprintf("#%d %q", existing.Number, node.Title)
+		}
+		return fmt.Sprintf("#%d", existing.Number)
+	}
+	if node.Key != "" {
+		return fmt.Sprintf("%q (%s)", node.Title, node.Key)
+	}
+	return node.Ref
+}
+
+// formatPlanSteps formats the computed changes as a human readable preview
+func formatPlanSteps(steps []PlanStep, state *PlanState) string {
+	var output strings.Builder
+	counts := make(map[string]int)
+
+	for _, step := range steps {
+		counts[step.Action]++
+		switch step.Action {
+		case "create":
+			output.WriteString(fmt.Sprintf("+ create   %s\n", planNodeLabel(step.Node, state)))
+		case "exists":
+			output.WriteString(fmt.Sprintf("= exists   %s\n", planNodeLabel(step.Node, state)))
+		case "link":
+			output.WriteString(fmt.Sprintf("~ link     %s → %s\n",
+				planNodeLabel(step.Node, state), planNodeLabel(step.Parent, state)))
+		case "move":
+			output.WriteString(fmt.Sprintf("~ move     %s → %s (from #%d)\n",
+				planNodeLabel(step.Node, state), planNodeLabel(step.Parent, state), state.Issues[step.Node].Parent.Number))
+		case "reorder":
+			output.WriteString(fmt.Sprintf("↕ reorder  children of %s\n", planNodeLabel(step.Parent, state)))
+		}
+	}
+
+	if counts["create"]+counts["link"]+counts["move"]+counts["reorder"] == 0 {
+		output.WriteString("\nNo changes. The hierarchy is up to date.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\nPlan: %d to create, %d to link, %d to move, %d to reorder.\n",
+		counts["create"], counts["link"], counts["move"], counts["reorder"]))
+	return output.String()
+}
+
+// hasPlanChanges reports whether any step changes the repository
+func hasPlanChanges(steps []PlanStep) bool {
+	for _, step := range steps {
+		if step.Action != "exists" {
+			return true
+		}
+	}
+	return false
+}
+
+// searchPlanKeys finds issues in a repository whose body contains a plan key marker
+func searchPlanKeys(client *api.GraphQLClient, owner, repo string) (map[string]*PlannedIssue, error) {
+	query := `
+		query($query: String!, $after: String) {
+			search(query: $query, type: ISSUE, first: 100, after: $after) {
+				nodes {
+					... on Issue {` + plannedIssueFields + `
+						body
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"query": fmt.Sprintf(`repo:%s/%s is:issue in:body "sub-issues:key"`, owner, repo),
+		"after": nil,
+	}
+
+	keys := make(map[string]*PlannedIssue)
+	for page := 0; page < 10; page++ {
+		var response struct {
+			Search struct {
+				Nodes []struct {
+					plannedIssue
+					Body string `json:"body"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"search"`
+		}
+
+		if err := client.Do(query, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		for _, node := range response.Search.Nodes {
+			if key := extractPlanKey(node.Body); key != "" {
+				keys[key] = node.toPlanned()
+			}
+		}
+
+		if !response.Search.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Search.PageInfo.EndCursor
+	}
+
+	return keys, nil
+}
+
+// getPlannedIssue fetches an issue referenced by a plan with its current parent
+func getPlannedIssue(client *api.GraphQLClient, ref *IssueReference) (*PlannedIssue, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {` + plannedIssueFields + `
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  ref.Owner,
+		"repo":   ref.Repo,
+		"number": ref.Number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue *plannedIssue `json:"issue"`
+		} `json:"repository"`
+	}
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", ref.Number, err)
+	}
+	if response.Repository.Issue == nil {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", ref.Number, ref.Owner, ref.Repo)
+	}
+	return response.Repository.Issue.toPlanned(), nil
+}
+
+// getSubIssueNodes fetches the ordered sub-issues of an issue by node ID, including bodies
+func getSubIssueNodes(client *api.GraphQLClient, issueID string) ([]IssueDetails, error) {
+	query := `
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					subIssues(first: 100) {
+						nodes {
+							id
+							number
+							title
+							body
+							state
+							url
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		Node struct {
+			SubIssues struct {
+				Nodes []IssueDetails `json:"nodes"`
+			} `json:"subIssues"`
+		} `json:"node"`
+	}
+
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get sub-issues: %w", err)
+	}
+
+	return response.Node.SubIssues.Nodes, nil
+}
+
+// resolvePlanState matches plan nodes with existing issues and their current sub-issues
+func resolvePlanState(client *api.GraphQLClient, plan *PlanFile, owner, repo string) (*PlanState, error) {
+	state := &PlanState{
+		Issues:   make(map[*PlanNode]*PlannedIssue),
+		Children: make(map[string][]string),
+	}
+
+	keys, err := searchPlanKeys(client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolve func(nodes []PlanNode) error
+	resolve = func(nodes []PlanNode) error {
+		for i := range nodes {
+			node := &nodes[i]
+
+			if node.Ref != "" {
+				ref := parseTaskReference(node.Ref, owner, repo)
+				if ref == nil {
+					parsed, err := parseIssueReference(node.Ref, owner, repo)
+					if err != nil {
+						return fmt.Errorf("invalid ref %q: %w", node.Ref, err)
+					}
+					ref = parsed
+				}
+				issue, err := getPlannedIssue(client, ref)
+				if err != nil {
+					return err
+				}
+				state.Issues[node] = issue
+			} else if existing, ok := keys[node.Key]; ok {
+				state.Issues[node] = existing
+			}
+
+			existing := state.Issues[node]
+			if existing == nil || len(node.Children) == 0 {
+				if err := resolve(node.Children); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Search results can lag behind, so also match keys on current sub-issues
+			children, err := getSubIssueNodes(client, existing.ID)
+			if err != nil {
+				return err
+			}
+			for _, child := range children {
+				state.Children[existing.ID] = append(state.Children[existing.ID], child.ID)
+				if key := extractPlanKey(child.Body); key != "" {
+					if _, ok := keys[key]; !ok {
+						keys[key] = &PlannedIssue{ID: child.ID, Number: child.Number, Parent: existing}
+					}
+				}
+			}
+
+			if err := resolve(node.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := resolve(plan.Issues); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// executePlanSteps performs the computed changes
+func executePlanSteps(cmd *cobra.Command, client *api.GraphQLClient, config *RepoConfig, steps []PlanStep, state *PlanState, owner, repo string) error {
+	milestones := make(map[string]int)
+
+	for _, step := range steps {
+		switch step.Action {
+		case "create":
+			node := step.Node
+			body := planKeyMarker(node.Key)
+			if node.Body != "" {
+				body = strings.TrimRight(node.Body, "\n") + "\n\n" + body
+			}
+			opts := CreateIssueOptions{
+				Title:     node.Title,
+				Body:      body,
+				Labels:    node.Labels,
+				Assignees: node.Assignees,
+			}
+			if node.Milestone != "" {
+				if _, ok := milestones[node.Milestone]; !ok {
+					number, err := findMilestoneNumber(owner, repo, node.Milestone)
+					if err != nil {
+						return err
+					}
+					milestones[node.Milestone] = number
+				}
+				opts.Milestone = milestones[node.Milestone]
+			}
+
+			fmt.Fprintf(cmd.OutOrStderr(), "Creating issue %q...\n", node.Title)
+			created, err := createIssue(owner, repo, opts)
+			if err != nil {
+				return err
+			}
+			state.Issues[node] = &PlannedIssue{ID: created.ID, Number: created.Number}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Created issue #%d %s\n", created.Number, node.Title)
+
+		case "link", "move":
+			parent := state.Issues[step.Parent]
+			child := state.Issues[step.Node]
+			if err := validateLink(client, config, parent.ID, child.ID); err != nil {
+				return fmt.Errorf("cannot add issue #%d to #%d: %w", child.Number, parent.Number, err)
+			}
+			if _, _, err := addSubIssue(client, parent.ID, child.ID, step.Action == "move"); err != nil {
+				return err
+			}
+			state.Children[parent.ID] = append(state.Children[parent.ID], child.ID)
+			if step.Action == "move" {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Moved issue #%d from #%d to #%d\n", child.Number, child.Parent.Number, parent.Number)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Added issue #%d as a sub-issue of #%d\n", child.Number, parent.Number)
+			}
+			child.Parent = parent
+
+		case "reorder":
+			parent := state.Issues[step.Parent]
+			for i := 1; i < len(step.Children); i++ {
+				child := state.Issues[step.Children[i]]
+				after := state.Issues[step.Children[i-1]]
+				if err := reprioritizeSubIssue(client, parent.ID, child.ID, after.ID, ""); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Reordered sub-issues of #%d\n", parent.Number)
+		}
+	}
+
+	return nil
+}
+
+// confirm asks a yes/no question on the terminal
+func confirm(cmd *cobra.Command, question string) (bool, error) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N] ", question)
+
+	reader := bufio.NewReader(cmd.InOrStdin())
+	answer, err := reader.ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// loadPlan reads the plan file and resolves it against the repository
+func loadPlan(cmd *cobra.Command) (*api.GraphQLClient, *PlanFile, *PlanState, string, string, error) {
+	data, err := os.ReadFile(applyFileFlag)
+	if err != nil {
+		return nil, nil, nil, "", "", fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	plan, err := parsePlanFile(data)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+
+	repoSpec := applyRepoFlag
+	if repoSpec == "" {
+		repoSpec = plan.Repo
+	}
+	owner, repo, err := resolveRepo(repoSpec)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return nil, nil, nil, "", "", fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Resolving plan against %s/%s...\n", owner, repo)
+	state, err := resolvePlanState(client, plan, owner, repo)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+
+	return client, plan, state, owner, repo, nil
+}
+
+// runPlan is the main command logic for plan
+func runPlan(cmd *cobra.Command, args []string) error {
+	_, plan, state, _, _, err := loadPlan(cmd)
+	if err != nil {
+		return err
+	}
+
+	steps := buildPlanSteps(plan.Issues, state)
+	fmt.Fprint(cmd.OutOrStdout(), formatPlanSteps(steps, state))
+	return nil
+}
+
+// runApply is the main command logic for apply
+func runApply(cmd *cobra.Command, args []string) error {
+	client, plan, state, owner, repo, err := loadPlan(cmd)
+	if err != nil {
+		return err
+	}
+
+	steps := buildPlanSteps(plan.Issues, state)
+	fmt.Fprint(cmd.OutOrStdout(), formatPlanSteps(steps, state))
+
+	if !hasPlanChanges(steps) {
+		return nil
+	}
+
+	if !applyYesFlag && dryRun == nil {
+		if !term.IsTerminal(os.Stdin) {
+			return fmt.Errorf("refusing to apply without confirmation (use --yes)")
+		}
+		ok, err := confirm(cmd, "Apply these changes?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("apply cancelled")
+		}
+	}
+
+	config, err := loadRepoConfig(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	return executePlanSteps(cmd, client, config, steps, state, owner, repo)
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePlanFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "valid plan",
+			input: `
+repo: owner/repo
+issues:
+  - key: auth
+    title: Authentication
+    labels: [epic]
+    children:
+      - key: auth-login
+        title: Login endpoint
+      - ref: "#95"
+`,
+		},
+		{
+			name:          "empty plan",
+			input:         "repo: owner/repo\n",
+			expectError:   true,
+			errorContains: "plan contains no issues",
+		},
+		{
+			name: "missing title",
+			input: `
+issues:
+  - key: auth
+`,
+			expectError:   true,
+			errorContains: "title is required",
+		},
+		{
+			name: "key and ref",
+			input: `
+issues:
+  - key: auth
+    ref: "#1"
+`,
+			expectError:   true,
+			errorContains: "mutually exclusive",
+		},
+		{
+			name: "duplicate key",
+			input: `
+issues:
+  - key: auth
+    title: One
+    children:
+      - key: auth
+        title: Two
+`,
+			expectError:   true,
+			errorContains: "duplicate key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := parsePlanFile([]byte(tt.input))
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+					return
+				}
+				if !containsString(err.Error(), tt.errorContains) {
+					t.Errorf("error message should contain '%s', got: %s", tt.errorContains, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if plan.Repo != "owner/repo" || len(plan.Issues) != 1 || len(plan.Issues[0].Children) != 2 {
+				t.Errorf("unexpected plan: %+v", plan)
+			}
+		})
+	}
+}
+
+func TestExtractPlanKey(t *testing.T) {
+	body := "Description\n\n" + planKeyMarker("auth-login")
+
+	if key := extractPlanKey(body); key != "auth-login" {
+		t.Errorf("extractPlanKey() = %q, want %q", key, "auth-login")
+	}
+	if key := extractPlanKey("no marker"); key != "" {
+		t.Errorf("extractPlanKey() = %q, want empty", key)
+	}
+}
+
+func TestBuildPlanSteps(t *testing.T) {
+	plan := &PlanFile{
+		Issues: []PlanNode{
+			{
+				Key:   "epic",
+				Title: "Epic",
+				Children: []PlanNode{
+					{Key: "a", Title: "A"},
+					{Key: "b", Title: "B"},
+				},
+			},
+		},
+	}
+	epic := &plan.Issues[0]
+	a := &epic.Children[0]
+	b := &epic.Children[1]
+
+	t.Run("nothing exists", func(t *testing.T) {
+		state := &PlanState{Issues: map[*PlanNode]*PlannedIssue{}, Children: map[string][]string{}}
+		steps := buildPlanSteps(plan.Issues, state)
+
+		expected := []string{"create", "create", "link", "create", "link", "reorder"}
+		assertPlanActions(t, steps, expected)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		state := &PlanState{
+			Issues: map[*PlanNode]*PlannedIssue{
+				epic: {ID: "E", Number: 1},
+				a:    {ID: "A", Number: 2},
+				b:    {ID: "B", Number: 3},
+			},
+			Children: map[string][]string{"E": {"X", "A", "B"}},
+		}
+		steps := buildPlanSteps(plan.Issues, state)
+
+		assertPlanActions(t, steps, []string{"exists", "exists", "exists"})
+		if hasPlanChanges(steps) {
+			t.Errorf("hasPlanChanges() = true, want false")
+		}
+	})
+
+	t.Run("wrong order", func(t *testing.T) {
+		state := &PlanState{
+			Issues: map[*PlanNode]*PlannedIssue{
+				epic: {ID: "E", Number: 1},
+				a:    {ID: "A", Number: 2},
+				b:    {ID: "B", Number: 3},
+			},
+			Children: map[string][]string{"E": {"B", "A"}},
+		}
+		steps := buildPlanSteps(plan.Issues, state)
+
+		assertPlanActions(t, steps, []string{"exists", "exists", "exists", "reorder"})
+	})
+
+	t.Run("child of another parent", func(t *testing.T) {
+		state := &PlanState{
+			Issues: map[*PlanNode]*PlannedIssue{
+				epic: {ID: "E", Number: 1},
+				a:    {ID: "A", Number: 2, Parent: &PlannedIssue{ID: "E", Number: 1}},
+				b:    {ID: "B", Number: 3, Parent: &PlannedIssue{ID: "O", Number: 9}},
+			},
+			Children: map[string][]string{"E": {"A"}},
+		}
+		steps := buildPlanSteps(plan.Issues, state)
+
+		assertPlanActions(t, steps, []string{"exists", "exists", "exists", "move", "reorder"})
+		if output := formatPlanSteps(steps, state); !strings.Contains(output, `~ move     #3 "B" → #1 "Epic" (from #9)`) ||
+			!strings.Contains(output, "Plan: 0 to create, 0 to link, 1 to move, 1 to reorder.") {
+			t.Errorf("formatPlanSteps() =\n%s", output)
+		}
+	})
+}
+
+func assertPlanActions(t *testing.T, steps []PlanStep, expected []string) {
+	t.Helper()
+
+	if len(steps) != len(expected) {
+		t.Fatalf("got %d steps, want %d: %+v", len(steps), len(expected), steps)
+	}
+	for i, step := range steps {
+		if step.Action != expected[i] {
+			t.Errorf("step %d action = %s, want %s", i, step.Action, expected[i])
+		}
+	}
+}
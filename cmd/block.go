@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	blockRepoFlag   string
+	unblockRepoFlag string
+)
+
+var blockCmd = &cobra.Command{
+	Use:   "block <issue> <blocking-issue>",
+	Short: "Mark an issue as blocked by another issue",
+	Long: `Add a blocked-by dependency between two issues.
+
+Blocked sub-issues are marked in list output, and their blockers are shown
+alongside them.
+
+Examples:
+  # Issue #12 cannot start until #10 is done
+  gh sub-issues block 12 10
+
+  # Cross-repository dependency
+  gh sub-issues block 12 https://github.com/owner/other-repo/issues/3`,
+	Args: cobra.ExactArgs(2),
+	RunE: runBlock,
+}
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock <issue> <blocking-issue>",
+	Short: "Remove a blocked-by dependency between two issues",
+	Long: `Remove a blocked-by dependency added with block.
+
+Examples:
+  gh sub-issues unblock 12 10
+  gh sub-issues unblock 12 https://github.com/owner/other-repo/issues/3`,
+	Args: cobra.ExactArgs(2),
+	RunE: runUnblock,
+}
+
+func init() {
+	// Add commands to root
+	rootCmd.AddCommand(blockCmd)
+	rootCmd.AddCommand(unblockCmd)
+
+	// Add flags
+	blockCmd.Flags().StringVarP(&blockRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	unblockCmd.Flags().StringVarP(&unblockRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+}
+
+// addBlockedBy marks an issue as blocked by another issue
+func addBlockedBy(client *api.GraphQLClient, issueID, blockingIssueID string) error {
+	mutation := `
+		mutation($issueId: ID!, $blockingIssueId: ID!) {
+			addBlockedBy(input: {
+				issueId: $issueId,
+				blockingIssueId: $blockingIssueId
+			}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"issueId":         issueID,
+		"blockingIssueId": blockingIssueID,
+	}
+	if recordMutation("addBlockedBy", variables,
+		fmt.Sprintf("Mark %s as blocked by %s", issueID, blockingIssueID), issueID, blockingIssueID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to add blocked-by dependency: %w", err)
+	}
+	links := journalIssues(client, issueID, blockingIssueID)
+	writeJournal(JournalEntry{Action: journalBlock, Issue: links[issueID], Blocker: links[blockingIssueID]})
+
+	return nil
+}
+
+// removeBlockedBy removes a blocked-by dependency between two issues
+func removeBlockedBy(client *api.GraphQLClient, issueID, blockingIssueID string) error {
+	mutation := `
+		mutation($issueId: ID!, $blockingIssueId: ID!) {
+			removeBlockedBy(input: {
+				issueId: $issueId,
+				blockingIssueId: $blockingIssueId
+			}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"issueId":         issueID,
+		"blockingIssueId": blockingIssueID,
+	}
+	if recordMutation("removeBlockedBy", variables,
+		fmt.Sprintf("Remove the dependency of %s on %s", issueID, blockingIssueID), issueID, blockingIssueID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to remove blocked-by dependency: %w", err)
+	}
+	links := journalIssues(client, issueID, blockingIssueID)
+	writeJournal(JournalEntry{Action: journalUnblock, Issue: links[issueID], Blocker: links[blockingIssueID]})
+
+	return nil
+}
+
+// openBlockers returns the blockers of a sub-issue that are still open
+func openBlockers(issue SubIssue) []IssueLink {
+	var open []IssueLink
+	for _, blocker := range issue.BlockedBy {
+		if blocker.State == "open" {
+			open = append(open, blocker)
+		}
+	}
+	return open
+}
+
+// linkRef returns a short reference to a linked issue, relative to a repository
+func linkRef(link IssueLink, repository string) string {
+	if link.Repository == "" || link.Repository == repository {
+		return fmt.Sprintf("#%d", link.Number)
+	}
+	return fmt.Sprintf("%s#%d", link.Repository, link.Number)
+}
+
+// blockerRefs lists the open blockers of a sub-issue, relative to its repository
+func blockerRefs(issue SubIssue) string {
+	repository := ""
+	if ref, err := parseIssueURL(issue.URL); err == nil {
+		repository = ref.Owner + "/" + ref.Repo
+	}
+
+	var refs []string
+	for _, blocker := range openBlockers(issue) {
+		refs = append(refs, linkRef(blocker, repository))
+	}
+	return strings.Join(refs, ", ")
+}
+
+// resolveDependency parses both issues of a dependency and fetches their node IDs
+func resolveDependency(cmd *cobra.Command, client *api.GraphQLClient, repoFlag string, args []string) (*IssueReference, string, *IssueReference, string, error) {
+	defaultOwner, defaultRepo, err := resolveRepo(repoFlag)
+	if err != nil {
+		return nil, "", nil, "", err
+	}
+
+	issueRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return nil, "", nil, "", fmt.Errorf("invalid issue: %w", err)
+	}
+	blockingRef, err := parseIssueReference(args[1], defaultOwner, defaultRepo)
+	if err != nil {
+		return nil, "", nil, "", fmt.Errorf("invalid blocking issue: %w", err)
+	}
+	if *issueRef == *blockingRef {
+		return nil, "", nil, "", fmt.Errorf("an issue cannot block itself")
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting issues #%d and #%d...\n", issueRef.Number, blockingRef.Number)
+	issueID, err := getIssueNodeID(client, issueRef.Owner, issueRef.Repo, issueRef.Number)
+	if err != nil {
+		return nil, "", nil, "", err
+	}
+	blockingID, err := getIssueNodeID(client, blockingRef.Owner, blockingRef.Repo, blockingRef.Number)
+	if err != nil {
+		return nil, "", nil, "", err
+	}
+
+	return issueRef, issueID, blockingRef, blockingID, nil
+}
+
+// runBlock is the main command logic for block
+func runBlock(cmd *cobra.Command, args []string) error {
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	issueRef, issueID, blockingRef, blockingID, err := resolveDependency(cmd, client, blockRepoFlag, args)
+	if err != nil {
+		return err
+	}
+
+	if err := addBlockedBy(client, issueID, blockingID); err != nil {
+		if strings.Contains(err.Error(), "already") {
+			return fmt.Errorf("issue #%d is already blocked by #%d", issueRef.Number, blockingRef.Number)
+		}
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Marked issue #%d as blocked by #%d\n", issueRef.Number, blockingRef.Number)
+	return nil
+}
+
+// runUnblock is the main command logic for unblock
+func runUnblock(cmd *cobra.Command, args []string) error {
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	issueRef, issueID, blockingRef, blockingID, err := resolveDependency(cmd, client, unblockRepoFlag, args)
+	if err != nil {
+		return err
+	}
+
+	if err := removeBlockedBy(client, issueID, blockingID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Issue #%d is no longer blocked by #%d\n", issueRef.Number, blockingRef.Number)
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBlockerRefs(t *testing.T) {
+	issue := SubIssue{
+		Number: 5,
+		State:  "open",
+		URL:    "https://github.com/owner/repo/issues/5",
+		BlockedBy: []IssueLink{
+			{Repository: "owner/repo", Number: 3, State: "open"},
+			{Repository: "owner/repo", Number: 4, State: "closed"},
+			{Repository: "other/project", Number: 9, State: "open"},
+		},
+	}
+
+	if got := len(openBlockers(issue)); got != 2 {
+		t.Errorf("openBlockers() returned %d blockers, want 2", got)
+	}
+	if got := blockerRefs(issue); got != "#3, other/project#9" {
+		t.Errorf("blockerRefs() = %q, want %q", got, "#3, other/project#9")
+	}
+	if got := blockerRefs(SubIssue{Number: 6}); got != "" {
+		t.Errorf("blockerRefs() without blockers = %q, want empty", got)
+	}
+}
+
+func TestFormatTTYBlocked(t *testing.T) {
+	result := &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Parent", State: "open"},
+		SubIssues: []SubIssue{
+			{Number: 2, Title: "Schema", State: "open", URL: "https://github.com/owner/repo/issues/2"},
+			{
+				Number: 3,
+				Title:  "Handlers",
+				State:  "open",
+				URL:    "https://github.com/owner/repo/issues/3",
+				BlockedBy: []IssueLink{
+					{Repository: "owner/repo", Number: 2, State: "open"},
+				},
+			},
+		},
+		Total:     2,
+		OpenCount: 2,
+	}
+
+	output := formatTTY(result, 80, &colorScheme{})
+	expected := []string{
+		"SUB-ISSUES (2 total, 2 open, 0 closed, 1 blocked)",
+		"ISSUE  TITLE       STATE   BLOCKED BY",
+		"#3     Handlers    open    #2",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("formatTTY() missing %q\nFull output:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+var browseRepoFlag string
+
+var browseCmd = &cobra.Command{
+	Use:   "browse <issue>",
+	Short: "Browse and edit an issue hierarchy in a full-screen terminal UI",
+	Long: `Open a full-screen terminal UI to navigate an issue hierarchy.
+
+Sub-issues are loaded when a node is expanded. Key bindings:
+  ↑/↓, k/j            Move the cursor
+  →/l, enter          Expand the focused issue
+  ←/h                 Collapse, or jump to the parent
+  shift+↑/↓, K/J      Move the focused sub-issue up or down
+  a                   Add an open issue as a sub-issue of the focused issue
+  u                   Unlink the focused sub-issue from its parent
+  c                   Close or reopen the focused issue
+  o                   Open the focused issue in the browser
+  r                   Reload the sub-issues of the focused issue
+  q, esc              Quit
+
+Examples:
+  gh sub-issues browse 123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBrowse,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(browseCmd)
+
+	// Add flags
+	browseCmd.Flags().StringVarP(&browseRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+}
+
+// browseNode is an issue shown in the browse tree
+type browseNode struct {
+	SubIssue
+	Owner    string
+	Repo     string
+	Parent   *browseNode
+	Children []*browseNode
+	Loaded   bool
+	Expanded bool
+}
+
+// browseRow is a visible line of the browse tree
+type browseRow struct {
+	Node  *browseNode
+	Depth int
+}
+
+// visibleRows flattens the expanded part of the tree
+func visibleRows(root *browseNode) []browseRow {
+	var rows []browseRow
+	var walk func(node *browseNode, depth int)
+	walk = func(node *browseNode, depth int) {
+		rows = append(rows, browseRow{Node: node, Depth: depth})
+		if node.Expanded {
+			for _, child := range node.Children {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(root, 0)
+	return rows
+}
+
+// siblingIndex returns the position of a node among its siblings
+func siblingIndex(node *browseNode) int {
+	if node.Parent == nil {
+		return -1
+	}
+	for i, sibling := range node.Parent.Children {
+		if sibling == node {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasAncestor reports whether id is the node itself or one of its ancestors
+func hasAncestor(node *browseNode, id string) bool {
+	for n := node; n != nil; n = n.Parent {
+		if n.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// browseSession is the state of a browse session
+type browseSession struct {
+	client *api.GraphQLClient
+	config *RepoConfig
+	scr    *screen
+	root   *browseNode
+	cursor int
+	status string
+}
+
+// loadChildren fetches the sub-issues of a node
+func (b *browseSession) loadChildren(node *browseNode) error {
+	result, err := getSubIssues(b.client, node.Owner, node.Repo, node.Number, 100, "all")
+	if err != nil {
+		return err
+	}
+
+	node.Children = nil
+	for _, issue := range result.SubIssues {
+		owner, repo := node.Owner, node.Repo
+		if ref, err := parseIssueURL(issue.URL); err == nil {
+			owner, repo = ref.Owner, ref.Repo
+		}
+		node.Children = append(node.Children, &browseNode{
+			SubIssue: issue,
+			Owner:    owner,
+			Repo:     repo,
+			Parent:   node,
+		})
+	}
+	node.SubIssueCount = len(node.Children)
+	node.Loaded = true
+	return nil
+}
+
+// render draws the tree, the details of the focused issue and the status line
+func (b *browseSession) render() {
+	width, height := b.scr.Size()
+	rows := visibleRows(b.root)
+	if b.cursor >= len(rows) {
+		b.cursor = len(rows) - 1
+	}
+	focused := rows[b.cursor].Node
+
+	detailHeight := 6
+	treeHeight := height - detailHeight - 2
+	if treeHeight < 3 {
+		treeHeight = 3
+	}
+
+	offset := 0
+	if b.cursor >= treeHeight {
+		offset = b.cursor - treeHeight + 1
+	}
+
+	var lines []string
+	for i := offset; i < len(rows) && i < offset+treeHeight; i++ {
+		row := rows[i]
+		node := row.Node
+
+		marker := "  "
+		if node.SubIssueCount > 0 {
+			marker = "▸ "
+			if node.Expanded {
+				marker = "▾ "
+			}
+		}
+
+		state := b.scr.Style("32", "●")
+		if node.State == "closed" {
+			state = b.scr.Style("35", "✓")
+		}
+
+		line := fmt.Sprintf("%s%s%s #%d %s", strings.Repeat("  ", row.Depth), marker, state, node.Number, node.Title)
+		if i == b.cursor {
+			line = b.scr.Style("7", line)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < treeHeight {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, b.scr.Style("2", strings.Repeat("─", width)))
+	lines = append(lines, b.scr.Style("1", fmt.Sprintf("#%d %s", focused.Number, focused.Title)))
+	lines = append(lines, fmt.Sprintf("State: %s   Repository: %s/%s", focused.State, focused.Owner, focused.Repo))
+	assignees := "none"
+	if len(focused.Assignees) > 0 {
+		assignees = "@" + strings.Join(focused.Assignees, ", @")
+	}
+	lines = append(lines, "Assignees: "+assignees)
+	lines = append(lines, fmt.Sprintf("Sub-issues: %d", focused.SubIssueCount))
+	lines = append(lines, b.scr.Style("4", focused.URL))
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	status := b.status
+	if status == "" {
+		status = "a: add  u: unlink  c: close/reopen  o: open  shift+↑/↓: reorder  q: quit"
+	}
+	lines = append(lines, b.scr.Style("2", status))
+
+	b.scr.Draw(lines)
+}
+
+// expand loads and expands a node
+func (b *browseSession) expand(node *browseNode) error {
+	if !node.Loaded && node.SubIssueCount > 0 {
+		b.status = fmt.Sprintf("Loading sub-issues of #%d...", node.Number)
+		b.render()
+		if err := b.loadChildren(node); err != nil {
+			return err
+		}
+	}
+	node.Expanded = len(node.Children) > 0
+	return nil
+}
+
+// toggleState closes an open issue or reopens a closed one
+func (b *browseSession) toggleState(node *browseNode) error {
+	state := "closed"
+	if node.State == "closed" {
+		state = "open"
+	}
+	if err := setIssueState(b.client, node.ID, state); err != nil {
+		return err
+	}
+	node.State = state
+	b.status = fmt.Sprintf("✓ Set #%d to %s", node.Number, state)
+	return nil
+}
+
+// unlink removes a node from its parent
+func (b *browseSession) unlink(node *browseNode) error {
+	parent := node.Parent
+	if parent == nil {
+		return fmt.Errorf("the root issue has no parent")
+	}
+	if err := removeSubIssue(b.client, parent.ID, node.ID); err != nil {
+		return err
+	}
+
+	i := siblingIndex(node)
+	parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+	parent.SubIssueCount = len(parent.Children)
+	parent.Expanded = len(parent.Children) > 0
+	b.status = fmt.Sprintf("✓ Removed #%d from #%d", node.Number, parent.Number)
+	return nil
+}
+
+// move swaps a node with its previous (delta -1) or next (delta 1) sibling
+func (b *browseSession) move(node *browseNode, delta int) error {
+	if node.Parent == nil {
+		return nil
+	}
+	siblings := node.Parent.Children
+	i := siblingIndex(node)
+	j := i + delta
+	if j < 0 || j >= len(siblings) {
+		return nil
+	}
+
+	var err error
+	if delta < 0 {
+		err = reprioritizeSubIssue(b.client, node.Parent.ID, node.ID, "", siblings[j].ID)
+	} else {
+		err = reprioritizeSubIssue(b.client, node.Parent.ID, node.ID, siblings[j].ID, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	siblings[i], siblings[j] = siblings[j], siblings[i]
+	b.cursor += delta * (1 + visibleDescendants(siblings[i]))
+	b.status = fmt.Sprintf("✓ Moved #%d", node.Number)
+	return nil
+}
+
+// visibleDescendants counts the visible rows below a node
+func visibleDescendants(node *browseNode) int {
+	return len(visibleRows(node)) - 1
+}
+
+// addChild picks an open issue and links it below the node
+func (b *browseSession) addChild(node *browseNode) error {
+	b.status = fmt.Sprintf("Loading open issues in %s/%s...", node.Owner, node.Repo)
+	b.render()
+
+	candidates, err := listOpenIssues(b.client, node.Owner, node.Repo, 100)
+	if err != nil {
+		return err
+	}
+
+	var items []PickerItem
+	for _, c := range candidates {
+		if !c.HasParent && !hasAncestor(node, c.ID) {
+			items = append(items, c.PickerItem)
+		}
+	}
+
+	picked, err := runPicker(b.scr, fmt.Sprintf("Add sub-issue to #%d", node.Number), items, false)
+	if err != nil || len(picked) == 0 {
+		b.status = ""
+		return err
+	}
+
+	if err := validateLink(b.client, b.config, node.ID, picked[0].ID); err != nil {
+		return fmt.Errorf("cannot add #%d: %w", picked[0].Number, err)
+	}
+	if _, _, err := addSubIssue(b.client, node.ID, picked[0].ID, false); err != nil {
+		return err
+	}
+	if err := b.loadChildren(node); err != nil {
+		return err
+	}
+	node.Expanded = true
+	b.status = fmt.Sprintf("✓ Added issue #%d as a sub-issue of #%d", picked[0].Number, node.Number)
+	return nil
+}
+
+// handleKey applies a key press, returning false when the session should end
+func (b *browseSession) handleKey(ev KeyEvent) (bool, error) {
+	rows := visibleRows(b.root)
+	node := rows[b.cursor].Node
+
+	key := ev.Key
+	if key == KeyRune {
+		switch ev.Rune {
+		case 'q':
+			return false, nil
+		case 'k':
+			key = KeyUp
+		case 'j':
+			key = KeyDown
+		case 'l':
+			key = KeyRight
+		case 'h':
+			key = KeyLeft
+		case 'K':
+			key = KeyShiftUp
+		case 'J':
+			key = KeyShiftDown
+		}
+	}
+
+	switch key {
+	case KeyEscape, KeyCtrlC:
+		return false, nil
+	case KeyUp:
+		if b.cursor > 0 {
+			b.cursor--
+		}
+	case KeyDown:
+		if b.cursor < len(rows)-1 {
+			b.cursor++
+		}
+	case KeyRight, KeyEnter:
+		return true, b.expand(node)
+	case KeyLeft:
+		if node.Expanded {
+			node.Expanded = false
+		} else if node.Parent != nil {
+			for i, row := range rows {
+				if row.Node == node.Parent {
+					b.cursor = i
+				}
+			}
+		}
+	case KeyShiftUp:
+		return true, b.move(node, -1)
+	case KeyShiftDown:
+		return true, b.move(node, 1)
+	case KeyRune:
+		switch ev.Rune {
+		case 'a':
+			return true, b.addChild(node)
+		case 'u':
+			if err := b.unlink(node); err != nil {
+				return true, err
+			}
+			if b.cursor > 0 {
+				b.cursor--
+			}
+		case 'c':
+			return true, b.toggleState(node)
+		case 'o':
+			if err := browser.New("", io.Discard, io.Discard).Browse(node.URL); err != nil {
+				return true, err
+			}
+			b.status = fmt.Sprintf("Opened %s", node.URL)
+		case 'r':
+			if err := b.loadChildren(node); err != nil {
+				return true, err
+			}
+			node.Expanded = len(node.Children) > 0
+			b.status = fmt.Sprintf("✓ Reloaded #%d", node.Number)
+		}
+	}
+	return true, nil
+}
+
+// runBrowse is the main command logic
+func runBrowse(cmd *cobra.Command, args []string) error {
+	defaultOwner, defaultRepo, err := resolveRepo(browseRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	result, err := getSubIssues(client, ref.Owner, ref.Repo, ref.Number, 100, "all")
+	if err != nil {
+		return err
+	}
+
+	root := &browseNode{
+		SubIssue: SubIssue{
+			ID:     result.Parent.ID,
+			Number: result.Parent.Number,
+			Title:  result.Parent.Title,
+			State:  result.Parent.State,
+			URL:    fmt.Sprintf("https://github.com/%s/%s/issues/%d", ref.Owner, ref.Repo, ref.Number),
+		},
+		Owner: ref.Owner,
+		Repo:  ref.Repo,
+	}
+	for _, issue := range result.SubIssues {
+		owner, repo := ref.Owner, ref.Repo
+		if r, err := parseIssueURL(issue.URL); err == nil {
+			owner, repo = r.Owner, r.Repo
+		}
+		root.Children = append(root.Children, &browseNode{SubIssue: issue, Owner: owner, Repo: repo, Parent: root})
+	}
+	root.SubIssueCount = len(root.Children)
+	root.Loaded = true
+	root.Expanded = true
+
+	config, err := loadRepoConfig(ref.Owner, ref.Repo)
+	if err != nil {
+		return err
+	}
+
+	scr, err := openScreen()
+	if err != nil {
+		return err
+	}
+	defer scr.Close()
+
+	session := &browseSession{client: client, config: config, scr: scr, root: root}
+	for {
+		session.render()
+
+		ev, err := scr.ReadKey()
+		if err != nil {
+			return err
+		}
+
+		// Every action is its own operation, so it can be undone on its own
+		startJournalOperation()
+		session.status = ""
+		more, err := session.handleKey(ev)
+		if err != nil {
+			session.status = "✗ " + err.Error()
+		}
+		if !more {
+			return nil
+		}
+	}
+}
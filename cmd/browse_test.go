@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestVisibleRows(t *testing.T) {
+	root := &browseNode{SubIssue: SubIssue{ID: "R", Number: 1}, Expanded: true}
+	a := &browseNode{SubIssue: SubIssue{ID: "A", Number: 2}, Parent: root, Expanded: true}
+	b := &browseNode{SubIssue: SubIssue{ID: "B", Number: 3}, Parent: root}
+	a1 := &browseNode{SubIssue: SubIssue{ID: "A1", Number: 4}, Parent: a}
+	b1 := &browseNode{SubIssue: SubIssue{ID: "B1", Number: 5}, Parent: b}
+	root.Children = []*browseNode{a, b}
+	a.Children = []*browseNode{a1}
+	b.Children = []*browseNode{b1}
+
+	rows := visibleRows(root)
+
+	expected := []struct {
+		number int
+		depth  int
+	}{
+		{1, 0}, {2, 1}, {4, 2}, {3, 1},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("visibleRows() returned %d rows, want %d", len(rows), len(expected))
+	}
+	for i, want := range expected {
+		if rows[i].Node.Number != want.number || rows[i].Depth != want.depth {
+			t.Errorf("row %d = #%d depth %d, want #%d depth %d",
+				i, rows[i].Node.Number, rows[i].Depth, want.number, want.depth)
+		}
+	}
+
+	if got := siblingIndex(b); got != 1 {
+		t.Errorf("siblingIndex(b) = %d, want 1", got)
+	}
+	if got := siblingIndex(root); got != -1 {
+		t.Errorf("siblingIndex(root) = %d, want -1", got)
+	}
+	if !hasAncestor(a1, "R") || hasAncestor(a1, "B") {
+		t.Errorf("hasAncestor() returned unexpected result")
+	}
+	if got := visibleDescendants(a); got != 1 {
+		t.Errorf("visibleDescendants(a) = %d, want 1", got)
+	}
+}
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	burndownRepoFlag          string
+	burndownSinceFlag         string
+	burndownUntilFlag         string
+	burndownFormatFlag        string
+	burndownEstimateLabelFlag string
+	burndownEstimateFieldFlag string
+	burndownProjectFlag       string
+)
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown <issue>",
+	Short: "Show open and closed sub-issues over time",
+	Long: `Build a daily series of open and closed descendants of an issue, for
+burndown and burnup charts.
+
+Each day counts the descendants created by the end of that day, and how many
+of them were closed. Issues can be weighted by an estimate instead of counted:
+with --estimate-label, the number after the label prefix is used (for example
+"estimate: 3"); with --estimate-field, a number field of a project. Issues
+without an estimate count as 0.
+
+In a terminal the series is drawn as a chart; otherwise it is written as CSV.
+
+Examples:
+  # Chart of the epic since the start of the quarter
+  gh sub-issues burndown 123 --since 2026-09-01
+
+  # Weighted by "points: N" labels, as CSV
+  gh sub-issues burndown 123 --estimate-label points --format csv
+
+  # Weighted by the Estimate field of project 7, as JSON
+  gh sub-issues burndown 123 --project 7 --estimate-field Estimate --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBurndown,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(burndownCmd)
+
+	// Add flags
+	burndownCmd.Flags().StringVarP(&burndownRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	burndownCmd.Flags().StringVar(&burndownSinceFlag, "since", "", "First day of the series, in YYYY-MM-DD format (default: when the issue was created)")
+	burndownCmd.Flags().StringVar(&burndownUntilFlag, "until", "", "Last day of the series, in YYYY-MM-DD format (default: today)")
+	burndownCmd.Flags().StringVar(&burndownFormatFlag, "format", "", "Output format: {chart|csv|json} (default: chart in a terminal, else csv)")
+	burndownCmd.Flags().StringVar(&burndownEstimateLabelFlag, "estimate-label", "", "Weight issues by the number in labels with this prefix")
+	burndownCmd.Flags().StringVar(&burndownEstimateFieldFlag, "estimate-field", "", "Weight issues by this number field of the project given by --project")
+	burndownCmd.Flags().StringVar(&burndownProjectFlag, "project", "", "Project of --estimate-field: {NUMBER|OWNER/NUMBER}")
+	burndownCmd.MarkFlagsMutuallyExclusive("estimate-label", "estimate-field")
+}
+
+// BurndownPoint is the state of a hierarchy at the end of one day
+type BurndownPoint struct {
+	Date   string  `json:"date"`
+	Open   float64 `json:"open"`
+	Closed float64 `json:"closed"`
+	Total  float64 `json:"total"`
+}
+
+// BurndownReport is the JSON output of burndown
+type BurndownReport struct {
+	Issue  IssueLink       `json:"issue"`
+	Weight string          `json:"weight"`
+	Series []BurndownPoint `json:"series"`
+}
+
+// burndownIssue is a descendant with its weight
+type burndownIssue struct {
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+	Weight    float64
+}
+
+// parseDay parses a YYYY-MM-DD date as the start of that day in local time
+func parseDay(value string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s (expected YYYY-MM-DD)", value)
+	}
+	return day, nil
+}
+
+// startOfDay truncates a time to midnight in local time
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// labelEstimate returns the number in the first label starting with prefix,
+// such as 3 for "estimate: 3" with prefix "estimate"
+func labelEstimate(labels []string, prefix string) (float64, bool) {
+	for _, label := range labels {
+		if len(label) < len(prefix) || !strings.EqualFold(label[:len(prefix)], prefix) {
+			continue
+		}
+		value := strings.TrimLeft(label[len(prefix):], " :=/-")
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// burndownSeries counts, for each day from since to until, the weight of
+// issues created by the end of the day and of those closed by then
+func burndownSeries(issues []burndownIssue, since, until time.Time) []BurndownPoint {
+	var points []BurndownPoint
+	for day := since; !day.After(until); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		point := BurndownPoint{Date: day.Format("2006-01-02")}
+		for _, issue := range issues {
+			if !issue.CreatedAt.Before(end) {
+				continue
+			}
+			point.Total += issue.Weight
+			if issue.ClosedAt != nil && issue.ClosedAt.Before(end) {
+				point.Closed += issue.Weight
+			}
+		}
+		point.Open = point.Total - point.Closed
+		points = append(points, point)
+	}
+	return points
+}
+
+// formatNumber formats a weight without trailing zeros
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// formatBurndownCSV writes the series as CSV with a header row
+func formatBurndownCSV(points []BurndownPoint) (string, error) {
+	var output strings.Builder
+	writer := csv.NewWriter(&output)
+	writer.Write([]string{"date", "open", "closed", "total"})
+	for _, point := range points {
+		writer.Write([]string{point.Date, formatNumber(point.Open), formatNumber(point.Closed), formatNumber(point.Total)})
+	}
+	writer.Flush()
+	return output.String(), writer.Error()
+}
+
+// sampleBurndown picks at most width evenly spaced points, keeping the first and last
+func sampleBurndown(points []BurndownPoint, width int) []BurndownPoint {
+	if len(points) <= width || width < 2 {
+		return points
+	}
+	sampled := make([]BurndownPoint, width)
+	for i := range sampled {
+		sampled[i] = points[i*(len(points)-1)/(width-1)]
+	}
+	return sampled
+}
+
+// formatBurndownChart draws the series as stacked columns of open and closed
+// work, one column per day, with height rows
+func formatBurndownChart(points []BurndownPoint, height, width int) string {
+	maxTotal := 0.0
+	for _, point := range points {
+		maxTotal = math.Max(maxTotal, point.Total)
+	}
+	if len(points) == 0 || maxTotal == 0 {
+		return "No sub-issues in this period\n"
+	}
+
+	labelWidth := len(formatNumber(maxTotal))
+	points = sampleBurndown(points, width-labelWidth-2)
+	rows := func(value float64) int {
+		return int(math.Round(value / maxTotal * float64(height)))
+	}
+
+	var output strings.Builder
+	for row := height; row >= 1; row-- {
+		label, axis := "", "│"
+		if row == height {
+			label, axis = formatNumber(maxTotal), "┤"
+		}
+		output.WriteString(fmt.Sprintf("%*s %s", labelWidth, label, axis))
+		for _, point := range points {
+			switch {
+			case row <= rows(point.Open):
+				output.WriteString("█")
+			case row <= rows(point.Total):
+				output.WriteString("░")
+			default:
+				output.WriteString(" ")
+			}
+		}
+		output.WriteString("\n")
+	}
+	output.WriteString(fmt.Sprintf("%*s └%s\n", labelWidth, "0", strings.Repeat("─", len(points))))
+
+	first, last := points[0].Date, points[len(points)-1].Date
+	indent := strings.Repeat(" ", labelWidth+2)
+	if gap := len(points) - len(first) - len(last); gap >= 1 {
+		output.WriteString(indent + first + strings.Repeat(" ", gap) + last + "\n")
+	} else {
+		output.WriteString(indent + first + " – " + last + "\n")
+	}
+
+	end := points[len(points)-1]
+	output.WriteString(fmt.Sprintf("\n█ open %s  ░ closed %s  of %s\n",
+		formatNumber(end.Open), formatNumber(end.Closed), formatNumber(end.Total)))
+	return output.String()
+}
+
+// runBurndown is the main command logic for burndown
+func runBurndown(cmd *cobra.Command, args []string) error {
+	format := burndownFormatFlag
+	t := term.FromEnv()
+	if format == "" {
+		format = "csv"
+		if t.IsTerminalOutput() {
+			format = "chart"
+		}
+	}
+	if format != "chart" && format != "csv" && format != "json" {
+		return fmt.Errorf("invalid format: %s (expected chart, csv or json)", format)
+	}
+	if burndownEstimateFieldFlag != "" && burndownProjectFlag == "" {
+		return fmt.Errorf("--estimate-field requires --project")
+	}
+
+	until := startOfDay(time.Now())
+	if burndownUntilFlag != "" {
+		var err error
+		if until, err = parseDay(burndownUntilFlag); err != nil {
+			return err
+		}
+	}
+
+	defaultOwner, defaultRepo, err := resolveRepo(burndownRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, 0)
+	if err != nil {
+		return err
+	}
+
+	since := startOfDay(root.CreatedAt)
+	if burndownSinceFlag != "" {
+		if since, err = parseDay(burndownSinceFlag); err != nil {
+			return err
+		}
+	}
+	if since.After(until) {
+		return fmt.Errorf("--since %s is after --until %s", since.Format("2006-01-02"), until.Format("2006-01-02"))
+	}
+
+	var nodes []*HierarchyNode
+	var ids []string
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		nodes = append(nodes, node)
+		ids = append(ids, node.ID)
+	})
+
+	weight := "count"
+	var fieldValues map[string]map[string]string
+	switch {
+	case burndownEstimateLabelFlag != "":
+		weight = "label:" + burndownEstimateLabelFlag
+	case burndownEstimateFieldFlag != "":
+		weight = "field:" + burndownEstimateFieldFlag
+		project, err := parseProjectRef(burndownProjectFlag, ref.Owner)
+		if err != nil {
+			return err
+		}
+		if fieldValues, _, err = getProjectFieldValues(client, ids, project); err != nil {
+			return err
+		}
+	}
+
+	issues := make([]burndownIssue, 0, len(nodes))
+	missing := 0
+	for _, node := range nodes {
+		issue := burndownIssue{CreatedAt: node.CreatedAt, Weight: 1}
+		if node.State == "closed" {
+			issue.ClosedAt = node.ClosedAt
+		}
+
+		ok := true
+		switch {
+		case burndownEstimateLabelFlag != "":
+			issue.Weight, ok = labelEstimate(node.Labels, burndownEstimateLabelFlag)
+		case burndownEstimateFieldFlag != "":
+			issue.Weight, ok = 0, false
+			for name, value := range fieldValues[node.ID] {
+				if strings.EqualFold(name, burndownEstimateFieldFlag) {
+					issue.Weight, err = strconv.ParseFloat(value, 64)
+					ok = err == nil
+				}
+			}
+		}
+		if !ok {
+			missing++
+		}
+		issues = append(issues, issue)
+	}
+	if missing > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "! %d of %d issues have no estimate and count as 0\n", missing, len(issues))
+	}
+
+	points := burndownSeries(issues, since, until)
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(BurndownReport{Issue: nodeLink(root), Weight: weight, Series: points}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "csv":
+		output, err := formatBurndownCSV(points)
+		if err != nil {
+			return fmt.Errorf("failed to format CSV: %w", err)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), output)
+	default:
+		width, _, err := t.Size()
+		if err != nil || width <= 0 {
+			width = 80
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Burndown of #%d %s\n\n", root.Number, root.Title)
+		fmt.Fprint(cmd.OutOrStdout(), formatBurndownChart(points, 12, min(width, 120)))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBurndownSeries(t *testing.T) {
+	day := func(d, hour int) time.Time {
+		return time.Date(2026, 9, d, hour, 0, 0, 0, time.Local)
+	}
+	closed := func(d, hour int) *time.Time {
+		at := day(d, hour)
+		return &at
+	}
+
+	issues := []burndownIssue{
+		{CreatedAt: day(1, 9), Weight: 3},
+		{CreatedAt: day(1, 10), ClosedAt: closed(2, 23), Weight: 2},
+		{CreatedAt: day(2, 0), ClosedAt: closed(3, 0), Weight: 1},
+		{CreatedAt: day(5, 0), Weight: 5},
+	}
+
+	got := burndownSeries(issues, day(1, 0), day(3, 0))
+	want := []BurndownPoint{
+		{Date: "2026-09-01", Open: 5, Closed: 0, Total: 5},
+		{Date: "2026-09-02", Open: 4, Closed: 2, Total: 6},
+		{Date: "2026-09-03", Open: 3, Closed: 3, Total: 6},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d points, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLabelEstimate(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   float64
+		ok     bool
+	}{
+		{[]string{"bug", "estimate: 3"}, 3, true},
+		{[]string{"Estimate/0.5"}, 0.5, true},
+		{[]string{"estimate-8"}, 8, true},
+		{[]string{"estimate: large"}, 0, false},
+		{[]string{"points: 3"}, 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := labelEstimate(tt.labels, "estimate")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("labelEstimate(%v) = %v, %v; want %v, %v", tt.labels, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatBurndownCSV(t *testing.T) {
+	output, err := formatBurndownCSV([]BurndownPoint{
+		{Date: "2026-09-01", Open: 4.5, Closed: 0, Total: 4.5},
+		{Date: "2026-09-02", Open: 2, Closed: 2.5, Total: 4.5},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "date,open,closed,total\n2026-09-01,4.5,0,4.5\n2026-09-02,2,2.5,4.5\n"
+	if output != want {
+		t.Errorf("formatBurndownCSV() = %q, want %q", output, want)
+	}
+}
+
+func TestFormatBurndownChart(t *testing.T) {
+	points := []BurndownPoint{
+		{Date: "2026-09-01", Open: 4, Closed: 0, Total: 4},
+		{Date: "2026-09-02", Open: 2, Closed: 2, Total: 4},
+		{Date: "2026-09-03", Open: 0, Closed: 4, Total: 4},
+	}
+
+	want := "4 ┤█░░\n" +
+		"  │██░\n" +
+		"0 └───\n" +
+		"   2026-09-01 – 2026-09-03\n" +
+		"\n█ open 0  ░ closed 4  of 4\n"
+	if got := formatBurndownChart(points, 2, 80); got != want {
+		t.Errorf("formatBurndownChart() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := formatBurndownChart(nil, 2, 80); got != "No sub-issues in this period\n" {
+		t.Errorf("formatBurndownChart(nil) = %q", got)
+	}
+}
+
+func TestSampleBurndown(t *testing.T) {
+	var points []BurndownPoint
+	for i := 0; i < 10; i++ {
+		points = append(points, BurndownPoint{Total: float64(i)})
+	}
+
+	sampled := sampleBurndown(points, 4)
+	var totals []string
+	for _, point := range sampled {
+		totals = append(totals, formatNumber(point.Total))
+	}
+	if got := strings.Join(totals, ","); got != "0,3,6,9" {
+		t.Errorf("sampleBurndown() totals = %s, want 0,3,6,9", got)
+	}
+	if len(sampleBurndown(points, 20)) != 10 {
+		t.Error("expected all points when they fit")
+	}
+}
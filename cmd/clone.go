@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneRepoFlag   string
+	cloneToFlag     string
+	cloneTitleFlag  string
+	cloneVarsFlag   []string
+	cloneDepthFlag  int
+	cloneStrictFlag bool
+
+	cloneCopyAssigneesFlag bool
+	cloneCopyMilestoneFlag bool
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <issue>",
+	Short: "Clone an issue and its sub-issues as a new hierarchy",
+	Long: `Duplicate an issue and all of its sub-issues into new issues, keeping titles,
+bodies, labels and the order of sub-issues. The copies are created open and linked
+in the same structure as the original. Assignees and milestones are only copied
+with --copy-assignees and --copy-milestone. Plan keys of "gh sub-issues apply"
+and the checklist of "gh sub-issues render-body" are removed from the copied
+bodies, as they belong to the original issues.
+
+Titles and bodies may contain {{name}} placeholders which are replaced with the
+values given by --var.
+
+Examples:
+  # Clone the release checklist for a new release
+  gh sub-issues clone 123 --title "Release {{version}}" --var version=2.4
+
+  # Clone into another repository
+  gh sub-issues clone 123 --to owner/other-repo
+
+  # Keep the assignees and milestone of the original issues
+  gh sub-issues clone 123 --copy-assignees --copy-milestone`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClone,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(cloneCmd)
+
+	// Add flags
+	cloneCmd.Flags().StringVarP(&cloneRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	cloneCmd.Flags().StringVar(&cloneToFlag, "to", "", "Target repository in OWNER/REPO format (default: source repository)")
+	cloneCmd.Flags().StringVarP(&cloneTitleFlag, "title", "t", "", "Title for the cloned parent issue")
+	cloneCmd.Flags().StringArrayVar(&cloneVarsFlag, "var", nil, "Template variable in NAME=VALUE format (repeatable)")
+	cloneCmd.Flags().IntVar(&cloneDepthFlag, "depth", 0, "Maximum depth of sub-issues to clone (0 for all)")
+	cloneCmd.Flags().BoolVar(&cloneStrictFlag, "strict", false, "Fail if a placeholder has no value")
+	cloneCmd.Flags().BoolVar(&cloneCopyAssigneesFlag, "copy-assignees", false, "Copy the assignees of the original issues")
+	cloneCmd.Flags().BoolVar(&cloneCopyMilestoneFlag, "copy-milestone", false, "Copy the milestone of the original issues")
+}
+
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][\w.-]*)\s*\}\}`)
+
+// parseTemplateVars parses NAME=VALUE pairs
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid variable: %s (expected NAME=VALUE)", pair)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// substituteVars replaces {{name}} placeholders and returns the names without a value
+func substituteVars(s string, vars map[string]string) (string, []string) {
+	var missing []string
+	result := templateVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		missing = append(missing, name)
+		return match
+	})
+	return result, missing
+}
+
+// missingTemplateVars collects placeholders without a value across a hierarchy
+func missingTemplateVars(root *HierarchyNode, title string, vars map[string]string) []string {
+	seen := make(map[string]bool)
+	collect := func(s string) {
+		_, missing := substituteVars(s, vars)
+		for _, name := range missing {
+			seen[name] = true
+		}
+	}
+
+	collect(title)
+	collect(root.Body)
+	if title == "" {
+		collect(root.Title)
+	}
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		collect(node.Title)
+		collect(node.Body)
+	})
+
+	var names []string
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runClone is the main command logic
+func runClone(cmd *cobra.Command, args []string) error {
+	vars, err := parseTemplateVars(cloneVarsFlag)
+	if err != nil {
+		return err
+	}
+
+	defaultOwner, defaultRepo, err := resolveRepo(cloneRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	targetOwner, targetRepo := ref.Owner, ref.Repo
+	if cloneToFlag != "" {
+		targetOwner, targetRepo, err = resolveRepo(cloneToFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, cloneDepthFlag)
+	if err != nil {
+		return err
+	}
+
+	if missing := missingTemplateVars(root, cloneTitleFlag, vars); len(missing) > 0 {
+		if cloneStrictFlag {
+			return fmt.Errorf("no value for placeholders: %s (use --var NAME=VALUE)", strings.Join(missing, ", "))
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "! No value for placeholders, leaving them as is: %s\n", strings.Join(missing, ", "))
+	}
+
+	creator, err := newHierarchyCreator(cmd, client, targetOwner, targetRepo)
+	if err != nil {
+		return err
+	}
+	creator.omitAssignees = !cloneCopyAssigneesFlag
+	creator.omitMilestone = !cloneCopyMilestoneFlag
+	creator.transform = func(node *HierarchyNode, isRoot bool) (string, string) {
+		title := node.Title
+		if isRoot && cloneTitleFlag != "" {
+			title = cloneTitleFlag
+		}
+		title, _ = substituteVars(title, vars)
+		body, _ := substituteVars(cloneBody(node.Body), vars)
+		return title, body
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Cloning into %s/%s...\n", targetOwner, targetRepo)
+	created, err := creator.create(root, "", 0)
+	creator.report(cmd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Cloned #%d into #%d with %d issues: %s\n",
+		root.Number, created.Number, creator.created, created.URL)
+	return nil
+}
+
+// cloneBody removes what ties a body to the original issue: the plan key that
+// apply matches issues by, and the checklist of the original sub-issues
+func cloneBody(body string) string {
+	cleaned := removeManagedSection(planKeyPattern.ReplaceAllString(body, ""))
+	if cleaned == body {
+		return body
+	}
+	return strings.TrimRight(cleaned, "\r\n")
+}
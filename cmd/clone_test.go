@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"version=2.4", "date=2026-10-01", "empty="})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"version": "2.4", "date": "2026-10-01", "empty": ""}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("parseTemplateVars() = %v, want %v", vars, expected)
+	}
+
+	if _, err := parseTemplateVars([]string{"novalue"}); err == nil {
+		t.Errorf("expected error for variable without '='")
+	}
+}
+
+func TestSubstituteVars(t *testing.T) {
+	vars := map[string]string{"version": "2.4"}
+
+	tests := []struct {
+		input    string
+		expected string
+		missing  []string
+	}{
+		{"Release {{version}}", "Release 2.4", nil},
+		{"Release {{ version }} notes", "Release 2.4 notes", nil},
+		{"Ship {{version}} on {{date}}", "Ship 2.4 on {{date}}", []string{"date"}},
+		{"No placeholders", "No placeholders", nil},
+	}
+
+	for _, tt := range tests {
+		result, missing := substituteVars(tt.input, vars)
+		if result != tt.expected {
+			t.Errorf("substituteVars(%q) = %q, want %q", tt.input, result, tt.expected)
+		}
+		if !reflect.DeepEqual(missing, tt.missing) {
+			t.Errorf("substituteVars(%q) missing = %v, want %v", tt.input, missing, tt.missing)
+		}
+	}
+}
+
+func TestMissingTemplateVars(t *testing.T) {
+	root := &HierarchyNode{
+		Title: "Release {{version}}",
+		Body:  "Checklist for {{version}}",
+		SubIssues: []*HierarchyNode{
+			{Title: "Tag {{version}}", Body: "Announce on {{date}}"},
+			{Title: "Notify {{team}}"},
+		},
+	}
+
+	missing := missingTemplateVars(root, "", map[string]string{"version": "2.4"})
+	expected := []string{"date", "team"}
+
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("missingTemplateVars() = %v, want %v", missing, expected)
+	}
+}
+
+func TestCloneOmitsAssigneesAndMilestone(t *testing.T) {
+	creator := &hierarchyCreator{
+		labels:        map[string]bool{"release": true},
+		skipped:       make(map[string]bool),
+		omitAssignees: true,
+		omitMilestone: true,
+	}
+	node := &HierarchyNode{Title: "Ship", Labels: []string{"release"}, Assignees: []string{"alice"}, Milestone: "v2.3"}
+
+	opts := creator.issueOptions(node, true)
+	if len(opts.Assignees) != 0 || opts.Milestone != 0 {
+		t.Errorf("expected no assignees or milestone, got %v and %d", opts.Assignees, opts.Milestone)
+	}
+	if !reflect.DeepEqual(opts.Labels, []string{"release"}) {
+		t.Errorf("labels = %v, want [release]", opts.Labels)
+	}
+}
+
+func TestCloneBody(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{"Ship it\n", "Ship it\n"},
+		{"Ship it\n\n<!-- sub-issues:key=release -->", "Ship it"},
+		{"Intro\n\n<!-- sub-issues:start -->\n- [ ] #2 Tag\n<!-- sub-issues:end -->\n\nNotes\n", "Intro\n\nNotes"},
+		{"<!-- sub-issues:start -->\n- [x] #3 Build\n<!-- sub-issues:end -->\n\n<!-- sub-issues:key=epic -->", ""},
+	}
+	for _, tt := range tests {
+		if got := cloneBody(tt.body); got != tt.expected {
+			t.Errorf("cloneBody(%q) = %q, want %q", tt.body, got, tt.expected)
+		}
+	}
+}
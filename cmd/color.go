@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// colorScheme styles terminal output, honoring NO_COLOR and CLICOLOR_FORCE
+type colorScheme struct {
+	enabled    bool
+	hyperlinks bool
+}
+
+// newColorScheme returns the color scheme for a terminal
+func newColorScheme(t term.Term) *colorScheme {
+	enabled := t.IsColorEnabled()
+	return &colorScheme{
+		enabled:    enabled,
+		hyperlinks: enabled && t.IsTerminalOutput() && os.Getenv("TERM") != "dumb",
+	}
+}
+
+// style wraps s in an ANSI SGR sequence when colors are enabled
+func (c *colorScheme) style(code, s string) string {
+	if !c.enabled || s == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// Bold renders s in bold
+func (c *colorScheme) Bold(s string) string {
+	return c.style("1", s)
+}
+
+// Green renders s in green
+func (c *colorScheme) Green(s string) string {
+	return c.style("32", s)
+}
+
+// Magenta renders s in magenta
+func (c *colorScheme) Magenta(s string) string {
+	return c.style("35", s)
+}
+
+// Yellow renders s in yellow
+func (c *colorScheme) Yellow(s string) string {
+	return c.style("33", s)
+}
+
+// Gray renders s in gray
+func (c *colorScheme) Gray(s string) string {
+	return c.style("90", s)
+}
+
+// Highlight renders s in reverse video
+func (c *colorScheme) Highlight(s string) string {
+	return c.style("7", s)
+}
+
+// ForState colors s by issue state, like GitHub does
+func (c *colorScheme) ForState(state, s string) string {
+	if state == "closed" {
+		return c.Magenta(s)
+	}
+	return c.Green(s)
+}
+
+// Hyperlink makes s a clickable OSC 8 link to url where supported
+func (c *colorScheme) Hyperlink(url, s string) string {
+	if !c.hyperlinks || url == "" {
+		return s
+	}
+	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, s)
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+// repoConfigPath is where the repository configuration is read from
+const repoConfigPath = ".github/sub-issues.yml"
+
+// configFlag is a local configuration file used instead of the repository's
+var configFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Read hierarchy rules from this file instead of "+repoConfigPath)
+}
+
+// RepoConfig holds the hierarchy rules of a repository
+type RepoConfig struct {
+	// IssueTypes maps an issue type to the issue types allowed as its sub-issues.
+	// Types that are not listed may have any sub-issues; an empty list allows none.
+	IssueTypes map[string][]string `yaml:"issueTypes"`
+
+	// Lint configures the checks of the lint command
+	Lint LintConfig `yaml:"lint"`
+
+	// allowedChildren is IssueTypes keyed by lower-case type name
+	allowedChildren map[string][]string
+}
+
+// LintConfig configures the checks of the lint command
+type LintConfig struct {
+	// MaxDepth is the deepest level of sub-issues allowed below a root issue, 0 for no limit
+	MaxDepth int `yaml:"maxDepth"`
+
+	// Disable lists checks that are not run
+	Disable []string `yaml:"disable"`
+}
+
+// parseRepoConfig parses a configuration file
+func parseRepoConfig(data []byte) (*RepoConfig, error) {
+	config := &RepoConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	config.allowedChildren = make(map[string][]string)
+	for parent, children := range config.IssueTypes {
+		key := strings.ToLower(strings.TrimSpace(parent))
+		if key == "" {
+			return nil, fmt.Errorf("invalid config: empty issue type name")
+		}
+		if _, ok := config.allowedChildren[key]; ok {
+			return nil, fmt.Errorf("invalid config: issue type %q is listed twice", parent)
+		}
+		config.allowedChildren[key] = append([]string{}, children...)
+	}
+	if config.Lint.MaxDepth < 0 {
+		return nil, fmt.Errorf("invalid config: lint.maxDepth must not be negative")
+	}
+	return config, nil
+}
+
+// loadRepoConfig reads the --config file, or the configuration file of a repository
+func loadRepoConfig(owner, repo string) (*RepoConfig, error) {
+	if configFlag != "" {
+		data, err := os.ReadFile(configFlag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		return parseRepoConfig(data)
+	}
+
+	client, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+	return fetchRepoConfig(client, owner, repo)
+}
+
+// fetchRepoConfig reads the configuration file of a repository. A repository
+// without a configuration file has no rules. Only a file that exists and is
+// invalid is an error: when it cannot be read, for example with a token that
+// cannot read contents, the rules are skipped with a warning.
+func fetchRepoConfig(client *api.RESTClient, owner, repo string) (*RepoConfig, error) {
+	var content struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, repoConfigPath)
+	if err := client.Get(path, &content); err != nil {
+		var httpErr *api.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+			fmt.Fprintf(os.Stderr, "! Failed to read %s from %s/%s, so its rules are not applied: %v\n", repoConfigPath, owner, repo, err)
+		}
+		return &RepoConfig{}, nil
+	}
+	if content.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding %q of %s", content.Encoding, repoConfigPath)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(content.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", repoConfigPath, err)
+	}
+	return parseRepoConfig(data)
+}
+
+// hasTypeRules reports whether the configuration restricts issue types
+func (c *RepoConfig) hasTypeRules() bool {
+	return c != nil && len(c.allowedChildren) > 0
+}
+
+// isContainerType reports whether the rules allow sub-issues below an issue type
+func (c *RepoConfig) isContainerType(issueType string) bool {
+	if c == nil || issueType == "" {
+		return false
+	}
+	allowed, ok := c.allowedChildren[strings.ToLower(issueType)]
+	return ok && len(allowed) > 0
+}
+
+// checkIssueTypes checks whether an issue of childType may be a sub-issue of parentType.
+// Issues without a type are not restricted.
+func (c *RepoConfig) checkIssueTypes(parentType, childType string) error {
+	if !c.hasTypeRules() || parentType == "" || childType == "" {
+		return nil
+	}
+
+	allowed, ok := c.allowedChildren[strings.ToLower(parentType)]
+	if !ok {
+		return nil
+	}
+	for _, t := range allowed {
+		if strings.EqualFold(t, childType) {
+			return nil
+		}
+	}
+
+	if len(allowed) == 0 {
+		return fmt.Errorf("%s cannot have sub-issues", parentType)
+	}
+	sorted := append([]string{}, allowed...)
+	sort.Strings(sorted)
+	return fmt.Errorf("%s cannot be a sub-issue of %s (allowed: %s)", childType, parentType, strings.Join(sorted, ", "))
+}
+
+// getIssueTypes fetches the issue type names of issues by node ID
+func getIssueTypes(client *api.GraphQLClient, ids ...string) (map[string]string, error) {
+	query := `
+		query($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				... on Issue {
+					id
+					issueType {
+						name
+					}
+				}
+			}
+		}`
+
+	var response struct {
+		Nodes []struct {
+			ID        string `json:"id"`
+			IssueType *struct {
+				Name string `json:"name"`
+			} `json:"issueType"`
+		} `json:"nodes"`
+	}
+
+	if err := client.Do(query, map[string]interface{}{"ids": ids}, &response); err != nil {
+		return nil, fmt.Errorf("failed to get issue types: %w", err)
+	}
+
+	types := make(map[string]string)
+	for _, node := range response.Nodes {
+		if node.IssueType != nil {
+			types[node.ID] = node.IssueType.Name
+		}
+	}
+	return types, nil
+}
+
+// validateLink checks the issue type rules before linking a sub-issue to a parent
+func validateLink(client *api.GraphQLClient, config *RepoConfig, parentID, subIssueID string) error {
+	if !config.hasTypeRules() || isPlannedNode(parentID) || isPlannedNode(subIssueID) {
+		return nil // Issues created by a dry run have no type yet
+	}
+
+	types, err := getIssueTypes(client, parentID, subIssueID)
+	if err != nil {
+		return err
+	}
+	return config.checkIssueTypes(types[parentID], types[subIssueID])
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const testRepoConfig = `
+issueTypes:
+  Initiative: [Epic]
+  Epic: [Task, Bug]
+  task: []
+`
+
+func TestParseRepoConfig(t *testing.T) {
+	config, err := parseRepoConfig([]byte(testRepoConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.hasTypeRules() {
+		t.Fatal("expected type rules")
+	}
+
+	empty, err := parseRepoConfig([]byte("# nothing configured\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if empty.hasTypeRules() {
+		t.Error("expected no type rules")
+	}
+
+	if _, err := parseRepoConfig([]byte("issueTypes:\n  Epic: [Task]\n  epic: [Bug]\n")); err == nil {
+		t.Error("expected error for duplicate issue type")
+	}
+	if _, err := parseRepoConfig([]byte("issueTypes: [Epic]\n")); err == nil {
+		t.Error("expected error for invalid issueTypes")
+	}
+}
+
+func TestCheckIssueTypes(t *testing.T) {
+	config, err := parseRepoConfig([]byte(testRepoConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		parent, child string
+		wantErr       string
+	}{
+		{"Initiative", "Epic", ""},
+		{"epic", "TASK", ""},
+		{"Epic", "Bug", ""},
+		{"Task", "Epic", "Task cannot have sub-issues"},
+		{"Epic", "Initiative", "Initiative cannot be a sub-issue of Epic (allowed: Bug, Task)"},
+		{"Bug", "Epic", ""},
+		{"", "Epic", ""},
+		{"Initiative", "", ""},
+	}
+
+	for _, tt := range tests {
+		err := config.checkIssueTypes(tt.parent, tt.child)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("checkIssueTypes(%q, %q) = %v, want nil", tt.parent, tt.child, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("checkIssueTypes(%q, %q) = %v, want %q", tt.parent, tt.child, err, tt.wantErr)
+		}
+	}
+
+	var none *RepoConfig
+	if err := none.checkIssueTypes("Task", "Epic"); err != nil {
+		t.Errorf("nil config: unexpected error %v", err)
+	}
+}
+
+func TestFetchRepoConfig(t *testing.T) {
+	encoded := func(config string) string {
+		return `{"encoding": "base64", "content": "` + base64.StdEncoding.EncodeToString([]byte(config)) + `"}`
+	}
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		rules   bool
+		wantErr bool
+	}{
+		{"valid file", http.StatusOK, encoded(testRepoConfig), true, false},
+		{"invalid file", http.StatusOK, encoded("lint:\n  maxDepth: -1\n"), false, true},
+		{"no file", http.StatusNotFound, `{"message": "Not Found"}`, false, false},
+		{"no access to contents", http.StatusForbidden, `{"message": "Resource not accessible by integration"}`, false, false},
+		{"empty repository", http.StatusConflict, `{"message": "Git Repository is empty."}`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := api.NewRESTClient(api.ClientOptions{
+				Host:      "github.com",
+				AuthToken: "token",
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+						Request:    req,
+					}, nil
+				}),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			config, err := fetchRepoConfig(client, "owner", "repo")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchRepoConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && config.hasTypeRules() != tt.rules {
+				t.Errorf("hasTypeRules() = %v, want %v", config.hasTypeRules(), tt.rules)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertRepoFlag   string
+	convertRemoveFlag bool
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert <parent-issue>",
+	Short: "Convert a task list in an issue body into sub-issues",
+	Long: `Convert markdown task list items in a parent issue body into real sub-issues.
+
+Items that start with an issue reference (#123, owner/repo#123 or an issue URL)
+are linked as sub-issues. Plain-text items are created as new issues in the
+parent's repository; checked items are closed after creation. Items whose title
+matches an existing sub-issue are not created again, so convert can be rerun.
+The resulting sub-issues keep the order of the task list.
+
+Examples:
+  # Convert the task list of issue #123
+  gh sub-issues convert 123
+
+  # Remove the migrated items from the parent body afterwards
+  gh sub-issues convert 123 --remove-tasks`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvert,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(convertCmd)
+
+	// Add flags
+	convertCmd.Flags().StringVarP(&convertRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	convertCmd.Flags().BoolVar(&convertRemoveFlag, "remove-tasks", false, "Remove migrated items from the parent issue body")
+}
+
+// TaskItem represents a task list item parsed from an issue body
+type TaskItem struct {
+	Line    int
+	Text    string
+	Checked bool
+	Ref     *IssueReference
+}
+
+var (
+	taskItemPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+?)\s*$`)
+	shortRefPattern = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+))?#(\d+)\b`)
+)
+
+// parseTaskList extracts task list items from a markdown body
+func parseTaskList(body, defaultOwner, defaultRepo string) []TaskItem {
+	var items []TaskItem
+	inFence := false
+	inManaged := false
+
+	for i, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		// Skip the checklist written by render-body
+		trimmed := strings.TrimSpace(line)
+		switch trimmed {
+		case managedSectionStart:
+			inManaged = true
+			continue
+		case managedSectionEnd:
+			inManaged = false
+			continue
+		}
+		if inManaged {
+			continue
+		}
+
+		// Skip task lists inside fenced code blocks
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		match := taskItemPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		item := TaskItem{
+			Line:    i,
+			Text:    match[2],
+			Checked: match[1] != " ",
+		}
+		item.Ref = parseTaskReference(item.Text, defaultOwner, defaultRepo)
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// parseTaskReference returns the issue referenced at the start of a task item,
+// or nil for plain text
+func parseTaskReference(text, defaultOwner, defaultRepo string) *IssueReference {
+	if strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://") {
+		ref, err := parseIssueURL(strings.Fields(text)[0])
+		if err != nil {
+			return nil
+		}
+		return ref
+	}
+
+	match := shortRefPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+
+	number, err := strconv.Atoi(match[3])
+	if err != nil || number <= 0 {
+		return nil
+	}
+
+	ref := &IssueReference{
+		Owner:  defaultOwner,
+		Repo:   defaultRepo,
+		Number: number,
+	}
+	if match[1] != "" {
+		ref.Owner = match[1]
+		ref.Repo = match[2]
+	}
+	return ref
+}
+
+// removeTaskLines removes the given line indexes from a markdown body
+func removeTaskLines(body string, lines []int) string {
+	remove := make(map[int]bool, len(lines))
+	for _, line := range lines {
+		remove[line] = true
+	}
+
+	var kept []string
+	for i, line := range strings.Split(body, "\n") {
+		if !remove[i] {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// getSubIssueIDs returns the node IDs of the current sub-issues of a parent issue
+func getSubIssueIDs(client *api.GraphQLClient, owner, repo string, number int) (map[string]bool, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					subIssues(first: 100) {
+						nodes {
+							id
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue struct {
+				SubIssues struct {
+					Nodes []struct {
+						ID string `json:"id"`
+					} `json:"nodes"`
+				} `json:"subIssues"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get sub-issues: %w", err)
+	}
+
+	ids := make(map[string]bool)
+	for _, node := range response.Repository.Issue.SubIssues.Nodes {
+		ids[node.ID] = true
+	}
+	return ids, nil
+}
+
+// runConvert is the main command logic
+func runConvert(cmd *cobra.Command, args []string) error {
+	defaultOwner, defaultRepo, err := resolveRepo(convertRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid parent issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n",
+		parentRef.Number, parentRef.Owner, parentRef.Repo)
+
+	parent, err := getIssueDetails(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return err
+	}
+
+	items := parseTaskList(parent.Body, parentRef.Owner, parentRef.Repo)
+	if len(items) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No task list items found in #%d\n", parent.Number)
+		return nil
+	}
+
+	current, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, 100, "all")
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(current.SubIssues))
+	titles := make(map[string]string, len(current.SubIssues))
+	for _, issue := range current.SubIssues {
+		existing[issue.ID] = true
+		titles[taskTitleKey(issue.Title)] = issue.ID
+	}
+
+	config, err := loadRepoConfig(parentRef.Owner, parentRef.Repo)
+	if err != nil {
+		return err
+	}
+
+	var orderedIDs []string
+	var migratedLines []int
+	failed := 0
+
+	for _, item := range items {
+		subID, err := convertTaskItem(cmd, client, config, parentRef, parent.ID, item, existing, titles)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "✗ %s: %v\n", item.Text, err)
+			failed++
+			continue
+		}
+		orderedIDs = append(orderedIDs, subID)
+		migratedLines = append(migratedLines, item.Line)
+	}
+
+	// Preserve the task list order
+	for i := 1; i < len(orderedIDs); i++ {
+		if err := reprioritizeSubIssue(client, parent.ID, orderedIDs[i], orderedIDs[i-1], ""); err != nil {
+			return err
+		}
+	}
+
+	if convertRemoveFlag && len(migratedLines) > 0 {
+		fmt.Fprintf(cmd.OutOrStderr(), "Updating body of #%d...\n", parent.Number)
+		if err := updateIssueBody(client, parent.ID, parent.Body, removeTaskLines(parent.Body, migratedLines)); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Converted %d task list items into sub-issues of #%d\n",
+		len(orderedIDs), parent.Number)
+
+	if failed > 0 {
+		return fmt.Errorf("%d task list items could not be converted", failed)
+	}
+	return nil
+}
+
+// taskTitleKey normalizes a title to match plain-text task items with existing sub-issues
+func taskTitleKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// convertTaskItem links or creates the issue for a single task item and returns
+// its node ID. titles maps the title keys of existing sub-issues to their IDs.
+func convertTaskItem(cmd *cobra.Command, client *api.GraphQLClient, config *RepoConfig, parentRef *IssueReference, parentID string, item TaskItem, existing map[string]bool, titles map[string]string) (string, error) {
+	var subID string
+
+	if item.Ref != nil {
+		if *item.Ref == *parentRef {
+			return "", fmt.Errorf("cannot add issue as its own sub-issue")
+		}
+
+		id, err := getIssueNodeID(client, item.Ref.Owner, item.Ref.Repo, item.Ref.Number)
+		if err != nil {
+			return "", err
+		}
+		subID = id
+
+		if existing[subID] {
+			fmt.Fprintf(cmd.OutOrStderr(), "Issue #%d is already a sub-issue, skipping link\n", item.Ref.Number)
+			return subID, nil
+		}
+	} else {
+		if id, ok := titles[taskTitleKey(item.Text)]; ok {
+			fmt.Fprintf(cmd.OutOrStderr(), "Sub-issue %q already exists, skipping\n", item.Text)
+			return id, nil
+		}
+
+		fmt.Fprintf(cmd.OutOrStderr(), "Creating issue %q...\n", item.Text)
+		created, err := createIssue(parentRef.Owner, parentRef.Repo, CreateIssueOptions{Title: item.Text})
+		if err != nil {
+			return "", err
+		}
+		subID = created.ID
+
+		if item.Checked {
+			if err := setIssueState(client, subID, "closed"); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := validateLink(client, config, parentID, subID); err != nil {
+		return "", err
+	}
+
+	_, subNum, err := addSubIssue(client, parentID, subID, false)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Added issue #%d as a sub-issue of #%d\n", subNum, parentRef.Number)
+	return subID, nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseTaskList(t *testing.T) {
+	body := "## Tasks\r\n" +
+		"- [ ] #12\r\n" +
+		"- [x] Write docs\n" +
+		"* [X] other/project#7\n" +
+		"  - [ ] https://github.com/owner/repo/issues/99\n" +
+		"- [ ] Fix #12 regression\n" +
+		"- [ ] #13 Implement login\n" +
+		"- [ ] https://github.com/owner/repo/issues/14 (follow-up)\n" +
+		"- [ ] #15th anniversary\n" +
+		"```\n" +
+		"- [ ] not a task\n" +
+		"```\n" +
+		"- plain bullet\n" +
+		"<!-- sub-issues:start -->\n" +
+		"- [ ] #42 Rendered item\n" +
+		"<!-- sub-issues:end -->\n"
+
+	items := parseTaskList(body, "owner", "repo")
+
+	expected := []struct {
+		line    int
+		text    string
+		checked bool
+		ref     *IssueReference
+	}{
+		{1, "#12", false, &IssueReference{Owner: "owner", Repo: "repo", Number: 12}},
+		{2, "Write docs", true, nil},
+		{3, "other/project#7", true, &IssueReference{Owner: "other", Repo: "project", Number: 7}},
+		{4, "https://github.com/owner/repo/issues/99", false, &IssueReference{Owner: "owner", Repo: "repo", Number: 99}},
+		{5, "Fix #12 regression", false, nil},
+		{6, "#13 Implement login", false, &IssueReference{Owner: "owner", Repo: "repo", Number: 13}},
+		{7, "https://github.com/owner/repo/issues/14 (follow-up)", false, &IssueReference{Owner: "owner", Repo: "repo", Number: 14}},
+		{8, "#15th anniversary", false, nil},
+	}
+
+	if len(items) != len(expected) {
+		t.Fatalf("parseTaskList() returned %d items, want %d: %+v", len(items), len(expected), items)
+	}
+
+	for i, want := range expected {
+		got := items[i]
+		if got.Line != want.line || got.Text != want.text || got.Checked != want.checked {
+			t.Errorf("item %d = {%d %q %v}, want {%d %q %v}",
+				i, got.Line, got.Text, got.Checked, want.line, want.text, want.checked)
+		}
+		if (got.Ref == nil) != (want.ref == nil) {
+			t.Errorf("item %d ref = %+v, want %+v", i, got.Ref, want.ref)
+			continue
+		}
+		if got.Ref != nil && *got.Ref != *want.ref {
+			t.Errorf("item %d ref = %+v, want %+v", i, *got.Ref, *want.ref)
+		}
+	}
+}
+
+func TestRemoveTaskLines(t *testing.T) {
+	body := "Intro\n- [ ] #1\n- [x] Done\nOutro"
+
+	output := removeTaskLines(body, []int{1, 2})
+	expected := "Intro\nOutro"
+
+	if output != expected {
+		t.Errorf("removeTaskLines() = %q, want %q", output, expected)
+	}
+}
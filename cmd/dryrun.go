@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// dryRunFlag is the format of the plan printed instead of making changes: text or json
+var dryRunFlag string
+
+// dryRun collects the mutations of a dry run; it is nil when changes are made
+var dryRun *dryRunRecorder
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&dryRunFlag, "dry-run", "", "Print the changes instead of making them: {text|json}")
+	rootCmd.PersistentFlags().Lookup("dry-run").NoOptDefVal = "text"
+
+	rootCmd.PersistentPreRunE = startDryRun
+}
+
+// PlannedMutation is a change that a dry run did not make
+type PlannedMutation struct {
+	Action      string                 `json:"action"`
+	Description string                 `json:"description"`
+	Input       map[string]interface{} `json:"input"`
+
+	// ids are the node IDs in the description, replaced by references when printed
+	ids []string
+}
+
+// plannedNode describes a node that a dry run did not create
+type plannedNode struct {
+	description string
+	ids         []string
+}
+
+// dryRunRecorder collects planned mutations; it is safe for concurrent use
+type dryRunRecorder struct {
+	mu        sync.Mutex
+	cmd       *cobra.Command
+	out       io.Writer
+	mutations []*PlannedMutation
+	planned   map[string]plannedNode
+	refs      map[string]string
+	numbers   map[string]int
+}
+
+// newDryRunRecorder returns a recorder that prints its plan to the output of cmd
+func newDryRunRecorder(cmd *cobra.Command) *dryRunRecorder {
+	return &dryRunRecorder{
+		cmd:     cmd,
+		out:     cmd.OutOrStdout(),
+		planned: make(map[string]plannedNode),
+		refs:    make(map[string]string),
+		numbers: make(map[string]int),
+	}
+}
+
+// startDryRun enables dry-run mode
+func startDryRun(cmd *cobra.Command, args []string) error {
+	switch dryRunFlag {
+	case "":
+		return nil
+	case "text", "json":
+	default:
+		return fmt.Errorf("invalid dry-run format: %s (expected text or json)", dryRunFlag)
+	}
+
+	dryRun = newDryRunRecorder(cmd)
+	return nil
+}
+
+// finishDryRun prints the plan of a dry run, if any. It runs after the command,
+// also when the command failed, so the changes planned up to the failure are shown.
+// Without changes, the plan goes to stderr to keep the command's output intact.
+func finishDryRun() error {
+	if dryRun == nil {
+		return nil
+	}
+	out := dryRun.out
+	if len(dryRun.mutations) == 0 {
+		out = dryRun.cmd.ErrOrStderr()
+	}
+
+	if ids := dryRun.allIDs(); len(ids) > 0 {
+		client, err := api.NewGraphQLClient(api.ClientOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+		dryRun.resolve(client, ids...)
+	}
+	dryRun.describe()
+
+	var output string
+	var err error
+	if dryRunFlag == "json" {
+		output, err = formatDryRunJSON(dryRun.mutations)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+	} else {
+		output = formatDryRunText(dryRun.mutations)
+	}
+	fmt.Fprint(out, output)
+	return nil
+}
+
+// recordMutation records a mutation instead of running it during a dry run, and
+// reports whether it did. Node IDs listed in ids are shown as issue references.
+func recordMutation(action string, input map[string]interface{}, description string, ids ...string) bool {
+	if dryRun == nil {
+		return false
+	}
+
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	if len(dryRun.mutations) == 0 {
+		// From the first change on, the usual output goes to stderr so stdout only carries the plan
+		dryRun.cmd.SetOut(dryRun.cmd.ErrOrStderr())
+	}
+	dryRun.mutations = append(dryRun.mutations, &PlannedMutation{
+		Action:      action,
+		Description: description,
+		Input:       input,
+		ids:         ids,
+	})
+	return true
+}
+
+// planNode returns a placeholder ID for a node that a dry run did not create
+func planNode(description string, ids ...string) string {
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	id := fmt.Sprintf("DRY_RUN_%d", len(dryRun.planned)+1)
+	dryRun.planned[id] = plannedNode{description: description, ids: ids}
+	return id
+}
+
+// isPlannedNode reports whether an ID is a placeholder of a dry run
+func isPlannedNode(id string) bool {
+	if dryRun == nil {
+		return false
+	}
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	_, ok := dryRun.planned[id]
+	return ok
+}
+
+// plannedIssueNumbers looks up the numbers of issues for the messages of skipped mutations
+func plannedIssueNumbers(client *api.GraphQLClient, ids ...string) []int {
+	dryRun.resolve(client, ids...)
+
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	numbers := make([]int, len(ids))
+	for i, id := range ids {
+		numbers[i] = dryRun.numbers[id]
+	}
+	return numbers
+}
+
+// allIDs returns every node ID mentioned by the recorded mutations
+func (r *dryRunRecorder) allIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []string
+	for _, mutation := range r.mutations {
+		ids = append(ids, mutation.ids...)
+	}
+	for _, node := range r.planned {
+		ids = append(ids, node.ids...)
+	}
+	return ids
+}
+
+// resolve looks up references for node IDs that are not known yet. IDs that
+// cannot be resolved are shown as they are.
+func (r *dryRunRecorder) resolve(client *api.GraphQLClient, ids ...string) {
+	r.mu.Lock()
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		_, planned := r.planned[id]
+		_, known := r.refs[id]
+		if id != "" && !planned && !known && !seen[id] {
+			unknown = append(unknown, id)
+			seen[id] = true
+		}
+	}
+	r.mu.Unlock()
+	if len(unknown) == 0 {
+		return
+	}
+
+	query := `
+		query($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				... on Issue {
+					id
+					number
+					repository {
+						nameWithOwner
+					}
+				}
+				... on ProjectV2 {
+					id
+					title
+				}
+				... on ProjectV2Item {
+					id
+					content {
+						... on Issue {
+							number
+							repository {
+								nameWithOwner
+							}
+						}
+					}
+				}
+				... on IssueType {
+					id
+					name
+				}
+			}
+		}`
+
+	var response struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			Number     int    `json:"number"`
+			Title      string `json:"title"`
+			Name       string `json:"name"`
+			Repository *struct {
+				NameWithOwner string `json:"nameWithOwner"`
+			} `json:"repository"`
+			Content *struct {
+				Number     int `json:"number"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+			} `json:"content"`
+		} `json:"nodes"`
+	}
+	if err := client.Do(query, map[string]interface{}{"ids": unknown}, &response); err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, node := range response.Nodes {
+		switch {
+		case node.Repository != nil:
+			r.refs[node.ID] = fmt.Sprintf("%s#%d", node.Repository.NameWithOwner, node.Number)
+			r.numbers[node.ID] = node.Number
+		case node.Content != nil:
+			r.refs[node.ID] = fmt.Sprintf("%s#%d", node.Content.Repository.NameWithOwner, node.Content.Number)
+		case node.Title != "":
+			r.refs[node.ID] = fmt.Sprintf("project %q", node.Title)
+		case node.Name != "":
+			r.refs[node.ID] = node.Name
+		}
+	}
+}
+
+// ref returns the reference shown for a node ID
+func (r *dryRunRecorder) ref(id string) string {
+	if node, ok := r.planned[id]; ok {
+		return r.expand(node.description, node.ids)
+	}
+	if ref, ok := r.refs[id]; ok {
+		return ref
+	}
+	return id
+}
+
+// expand replaces node IDs in a description with their references
+func (r *dryRunRecorder) expand(description string, ids []string) string {
+	var pairs []string
+	for _, id := range ids {
+		if id != "" {
+			pairs = append(pairs, id, r.ref(id))
+		}
+	}
+	return strings.NewReplacer(pairs...).Replace(description)
+}
+
+// describe replaces node IDs in the descriptions of all mutations
+func (r *dryRunRecorder) describe() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, mutation := range r.mutations {
+		mutation.Description = r.expand(mutation.Description, mutation.ids)
+	}
+}
+
+// formatDryRunText formats planned mutations as a numbered list
+func formatDryRunText(mutations []*PlannedMutation) string {
+	if len(mutations) == 0 {
+		return "Dry run: no changes would be made\n"
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Dry run: %d changes would be made\n", len(mutations)))
+	for i, mutation := range mutations {
+		output.WriteString(fmt.Sprintf("  %d. %s\n", i+1, mutation.Description))
+	}
+	return output.String()
+}
+
+// formatDryRunJSON formats planned mutations as JSON
+func formatDryRunJSON(mutations []*PlannedMutation) (string, error) {
+	if mutations == nil {
+		mutations = []*PlannedMutation{}
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"mutations": mutations}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// joinSorted joins values in sorted order, for stable descriptions
+func joinSorted(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRecordMutation(t *testing.T) {
+	if recordMutation("closeIssue", nil, "Close I_1", "I_1") {
+		t.Fatal("expected no recording outside a dry run")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	dryRun = newDryRunRecorder(cmd)
+	defer func() { dryRun = nil }()
+	dryRun.refs["I_1"] = "owner/repo#1"
+	dryRun.refs["P_1"] = `project "Roadmap"`
+
+	if cmd.OutOrStdout() != &stdout {
+		t.Fatal("expected the usual output before the first change")
+	}
+
+	created := planNode(`new issue "Child"`)
+	if !isPlannedNode(created) || isPlannedNode("I_1") {
+		t.Fatalf("isPlannedNode(%q) mismatch", created)
+	}
+	item := planNode(created, created)
+
+	if !recordMutation("addSubIssue", nil, "Add "+created+" as a sub-issue of I_1", created, "I_1") {
+		t.Fatal("expected recording during a dry run")
+	}
+	if cmd.OutOrStdout() != &stderr {
+		t.Error("expected the output to go to stderr once a change is planned")
+	}
+	recordMutation("updateProjectItemField", nil, `Set Status of `+item+` in P_1 to "Done"`, item, "P_1")
+	recordMutation("closeIssue", nil, "Close I_2", "I_2")
+	dryRun.describe()
+
+	want := []string{
+		`Add new issue "Child" as a sub-issue of owner/repo#1`,
+		`Set Status of new issue "Child" in project "Roadmap" to "Done"`,
+		"Close I_2",
+	}
+	if len(dryRun.mutations) != len(want) {
+		t.Fatalf("got %d mutations, want %d", len(dryRun.mutations), len(want))
+	}
+	for i, mutation := range dryRun.mutations {
+		if mutation.Description != want[i] {
+			t.Errorf("mutation %d = %q, want %q", i, mutation.Description, want[i])
+		}
+	}
+}
+
+func TestFormatDryRunText(t *testing.T) {
+	mutations := []*PlannedMutation{
+		{Action: "addSubIssue", Description: "Add owner/repo#2 as a sub-issue of owner/repo#1"},
+		{Action: "closeIssue", Description: "Close owner/repo#1"},
+	}
+
+	want := "Dry run: 2 changes would be made\n" +
+		"  1. Add owner/repo#2 as a sub-issue of owner/repo#1\n" +
+		"  2. Close owner/repo#1\n"
+	if got := formatDryRunText(mutations); got != want {
+		t.Errorf("formatDryRunText() =\n%s\nwant:\n%s", got, want)
+	}
+	if got := formatDryRunText(nil); got != "Dry run: no changes would be made\n" {
+		t.Errorf("formatDryRunText(nil) = %q", got)
+	}
+}
+
+func TestFormatDryRunJSON(t *testing.T) {
+	output, err := formatDryRunJSON(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "{\n  \"mutations\": []\n}\n" {
+		t.Errorf("formatDryRunJSON(nil) = %q", output)
+	}
+
+	mutations := []*PlannedMutation{{
+		Action:      "addSubIssue",
+		Description: "Add owner/repo#2 as a sub-issue of owner/repo#1",
+		Input:       map[string]interface{}{"parentId": "I_1", "subIssueId": "I_2"},
+	}}
+	output, err = formatDryRunJSON(mutations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result struct {
+		Mutations []PlannedMutation `json:"mutations"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Mutations) != 1 || result.Mutations[0].Action != "addSubIssue" ||
+		result.Mutations[0].Input["subIssueId"] != "I_2" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
@@ -0,0 +1,449 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	exportRepoFlag    string
+	exportOutputFlag  string
+	exportFormatFlag  string
+	importFileFlag    string
+	importRepoFlag    string
+	importMappingFlag string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <issue>",
+	Short: "Export an issue hierarchy to a JSON or YAML file",
+	Long: `Export an issue and all of its sub-issues, recursively, to a portable file.
+
+The file contains titles, bodies, states, labels, assignees, milestones and the
+order of sub-issues, and can be recreated elsewhere with import.
+
+Examples:
+  # Export to stdout as JSON
+  gh sub-issues export 123
+
+  # Export to a YAML file
+  gh sub-issues export 123 -o epic.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import -f <file>",
+	Short: "Recreate an exported issue hierarchy in a repository",
+	Long: `Recreate a hierarchy written by export as new issues in a repository.
+
+Users, labels and milestones can be renamed with a mapping file. Mapping a value
+to an empty string drops it. Anything that does not exist in the target
+repository is skipped and reported at the end.
+
+Mapping file format:
+  users:
+    alice: alice-work
+  labels:
+    bug: "type: bug"
+  milestones:
+    v1.0: "2026 Q1"
+
+Examples:
+  gh sub-issues import -f epic.yaml --repo owner/other-repo
+  gh sub-issues import -f epic.json --mapping mapping.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runImport,
+}
+
+func init() {
+	// Add commands to root
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+
+	// Add flags
+	exportCmd.Flags().StringVarP(&exportRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	exportCmd.Flags().StringVarP(&exportOutputFlag, "output", "o", "", "Write to file instead of stdout")
+	exportCmd.Flags().StringVar(&exportFormatFlag, "format", "", "Output format: {json|yaml} (default: from file extension, else json)")
+
+	importCmd.Flags().StringVarP(&importFileFlag, "file", "f", "", "Path to the exported file (required)")
+	importCmd.Flags().StringVarP(&importRepoFlag, "repo", "R", "", "Target repository in OWNER/REPO format")
+	importCmd.Flags().StringVar(&importMappingFlag, "mapping", "", "Path to a YAML file mapping users, labels and milestones")
+	importCmd.MarkFlagRequired("file")
+}
+
+// HierarchyExport is the portable file format written by export
+type HierarchyExport struct {
+	Version    int            `json:"version" yaml:"version"`
+	ExportedAt time.Time      `json:"exportedAt" yaml:"exportedAt"`
+	Root       *HierarchyNode `json:"root" yaml:"root"`
+}
+
+// ImportMapping renames users, labels and milestones during import
+type ImportMapping struct {
+	Users      map[string]string `yaml:"users"`
+	Labels     map[string]string `yaml:"labels"`
+	Milestones map[string]string `yaml:"milestones"`
+}
+
+// exportFormat determines the export format from the flag or the file extension
+func exportFormat(format, path string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			return "yaml", nil
+		default:
+			return "json", nil
+		}
+	}
+	if format != "json" && format != "yaml" {
+		return "", fmt.Errorf("invalid format: %s (expected json or yaml)", format)
+	}
+	return format, nil
+}
+
+// marshalHierarchy encodes a hierarchy file in the given format
+func marshalHierarchy(data *HierarchyExport, format string) ([]byte, error) {
+	if format == "yaml" {
+		return yaml.Marshal(data)
+	}
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// readHierarchyFile reads a file written by export. YAML is a superset of JSON,
+// so both formats are decoded the same way.
+func readHierarchyFile(path string) (*HierarchyExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var export HierarchyExport
+	if err := yaml.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if export.Root == nil {
+		return nil, fmt.Errorf("%s does not contain a hierarchy", path)
+	}
+	return &export, nil
+}
+
+// mapValue applies a mapping, reporting whether the value should be kept
+func mapValue(mapping map[string]string, value string) (string, bool) {
+	if mapped, ok := mapping[value]; ok {
+		return mapped, mapped != ""
+	}
+	return value, true
+}
+
+// hierarchyCreator recreates hierarchy nodes as new issues in a repository
+type hierarchyCreator struct {
+	cmd        *cobra.Command
+	client     *api.GraphQLClient
+	rest       *api.RESTClient
+	owner      string
+	repo       string
+	mapping    ImportMapping
+	transform  func(node *HierarchyNode, isRoot bool) (string, string)
+	keepState  bool
+	labels     map[string]bool
+	assignable map[string]error
+	milestones map[string]int
+	skipped    map[string]bool
+	created    int
+
+	// omitAssignees and omitMilestone leave assignees and milestones off the new issues
+	omitAssignees bool
+	omitMilestone bool
+}
+
+// newHierarchyCreator prepares a creator for the target repository
+func newHierarchyCreator(cmd *cobra.Command, client *api.GraphQLClient, owner, repo string) (*hierarchyCreator, error) {
+	rest, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	c := &hierarchyCreator{
+		cmd:        cmd,
+		client:     client,
+		rest:       rest,
+		owner:      owner,
+		repo:       repo,
+		labels:     make(map[string]bool),
+		assignable: make(map[string]error),
+		milestones: make(map[string]int),
+		skipped:    make(map[string]bool),
+	}
+
+	for page := 1; ; page++ {
+		var labels []struct {
+			Name string `json:"name"`
+		}
+		path := fmt.Sprintf("repos/%s/%s/labels?per_page=100&page=%d", owner, repo, page)
+		if err := rest.Get(path, &labels); err != nil {
+			return nil, fmt.Errorf("failed to get labels of %s/%s: %w", owner, repo, err)
+		}
+		for _, label := range labels {
+			c.labels[label.Name] = true
+		}
+		if len(labels) < 100 {
+			break
+		}
+	}
+
+	return c, nil
+}
+
+// skip records something that could not be carried over
+func (c *hierarchyCreator) skip(format string, args ...interface{}) {
+	c.skipped[fmt.Sprintf(format, args...)] = true
+}
+
+// errNotAssignable is returned by checkAssignable for users who cannot be assigned
+var errNotAssignable = errors.New("cannot be assigned")
+
+// checkAssignable checks whether a user can be assigned in the target
+// repository. Only a 204 response confirms it; a 404 means the user cannot be
+// assigned, and any other failure is returned as is.
+func (c *hierarchyCreator) checkAssignable(login string) error {
+	if err, cached := c.assignable[login]; cached {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/assignees/%s", c.owner, c.repo, login)
+	resp, err := c.rest.Request(http.MethodGet, path, nil)
+	var httpErr *api.HTTPError
+	switch {
+	case err == nil:
+		if resp.StatusCode != http.StatusNoContent {
+			err = errNotAssignable
+		}
+		resp.Body.Close()
+	case errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound:
+		err = errNotAssignable
+	}
+	c.assignable[login] = err
+	return err
+}
+
+// issueOptions maps a node onto the fields available in the target repository
+func (c *hierarchyCreator) issueOptions(node *HierarchyNode, isRoot bool) CreateIssueOptions {
+	opts := CreateIssueOptions{Title: node.Title, Body: node.Body}
+	if c.transform != nil {
+		opts.Title, opts.Body = c.transform(node, isRoot)
+	}
+
+	for _, label := range node.Labels {
+		mapped, keep := mapValue(c.mapping.Labels, label)
+		switch {
+		case !keep:
+		case c.labels[mapped]:
+			opts.Labels = append(opts.Labels, mapped)
+		default:
+			c.skip("label %q does not exist in %s/%s", mapped, c.owner, c.repo)
+		}
+	}
+
+	assignees := node.Assignees
+	if c.omitAssignees {
+		assignees = nil
+	}
+	for _, login := range assignees {
+		mapped, keep := mapValue(c.mapping.Users, login)
+		if !keep {
+			continue
+		}
+		switch err := c.checkAssignable(mapped); {
+		case err == nil:
+			opts.Assignees = append(opts.Assignees, mapped)
+		case errors.Is(err, errNotAssignable):
+			c.skip("user @%s cannot be assigned in %s/%s", mapped, c.owner, c.repo)
+		default:
+			c.skip("could not check whether @%s can be assigned in %s/%s: %v", mapped, c.owner, c.repo, err)
+		}
+	}
+
+	if node.Milestone != "" && !c.omitMilestone {
+		if mapped, keep := mapValue(c.mapping.Milestones, node.Milestone); keep {
+			number, cached := c.milestones[mapped]
+			if !cached {
+				n, err := findMilestoneNumber(c.owner, c.repo, mapped)
+				switch {
+				case errors.Is(err, errMilestoneNotFound):
+					c.skip("milestone %q does not exist in %s/%s", mapped, c.owner, c.repo)
+				case err != nil:
+					c.skip("milestone %q: %v", mapped, err)
+				}
+				number = n
+				c.milestones[mapped] = n
+			}
+			opts.Milestone = number
+		}
+	}
+
+	return opts
+}
+
+// create recreates a node and its sub-issues, linking them below parentID
+func (c *hierarchyCreator) create(node *HierarchyNode, parentID string, parentNumber int) (*CreatedIssue, error) {
+	opts := c.issueOptions(node, parentID == "")
+
+	created, err := createIssue(c.owner, c.repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.created++
+	fmt.Fprintf(c.cmd.OutOrStdout(), "✓ Created issue #%d (from %s#%d) %s\n",
+		created.Number, node.Repository, node.Number, opts.Title)
+
+	if c.keepState && node.State == "closed" {
+		if err := setIssueState(c.client, created.ID, "closed"); err != nil {
+			return nil, err
+		}
+	}
+
+	if parentID != "" {
+		if _, _, err := addSubIssue(c.client, parentID, created.ID, false); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(c.cmd.OutOrStderr(), "Added issue #%d as a sub-issue of #%d\n", created.Number, parentNumber)
+	}
+
+	// Sub-issues are appended in order, so the original ordering is kept
+	for _, child := range node.SubIssues {
+		if _, err := c.create(child, created.ID, created.Number); err != nil {
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+// report prints everything that could not be carried over
+func (c *hierarchyCreator) report(cmd *cobra.Command) {
+	if len(c.skipped) == 0 {
+		return
+	}
+
+	var lines []string
+	for line := range c.skipped {
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "\nNot carried over:\n")
+	for _, line := range lines {
+		fmt.Fprintf(cmd.ErrOrStderr(), "  - %s\n", line)
+	}
+}
+
+// runExport is the main command logic for export
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := exportFormat(exportFormatFlag, exportOutputFlag)
+	if err != nil {
+		return err
+	}
+
+	defaultOwner, defaultRepo, err := resolveRepo(exportRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, 0)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalHierarchy(&HierarchyExport{
+		Version:    1,
+		ExportedAt: time.Now().UTC(),
+		Root:       root,
+	}, format)
+	if err != nil {
+		return fmt.Errorf("failed to encode hierarchy: %w", err)
+	}
+
+	if exportOutputFlag == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(exportOutputFlag, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutputFlag, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Exported hierarchy of #%d to %s\n", root.Number, exportOutputFlag)
+	return nil
+}
+
+// runImport is the main command logic for import
+func runImport(cmd *cobra.Command, args []string) error {
+	export, err := readHierarchyFile(importFileFlag)
+	if err != nil {
+		return err
+	}
+
+	var mapping ImportMapping
+	if importMappingFlag != "" {
+		data, err := os.ReadFile(importMappingFlag)
+		if err != nil {
+			return fmt.Errorf("failed to read mapping file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &mapping); err != nil {
+			return fmt.Errorf("failed to parse mapping file: %w", err)
+		}
+	}
+
+	owner, repo, err := resolveRepo(importRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	creator, err := newHierarchyCreator(cmd, client, owner, repo)
+	if err != nil {
+		return err
+	}
+	creator.mapping = mapping
+	creator.keepState = true
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Importing hierarchy into %s/%s...\n", owner, repo)
+	root, err := creator.create(export.Root, "", 0)
+	creator.report(cmd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Imported %d issues into %s/%s (root #%d)\n", creator.created, owner, repo, root.Number)
+	return nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{"", "", "json", false},
+		{"", "epic.yaml", "yaml", false},
+		{"", "epic.YML", "yaml", false},
+		{"", "epic.json", "json", false},
+		{"json", "epic.yaml", "json", false},
+		{"xml", "", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := exportFormat(tt.format, tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("exportFormat(%q, %q) error = %v, wantErr %v", tt.format, tt.path, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("exportFormat(%q, %q) = %q, want %q", tt.format, tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestHierarchyRoundTrip(t *testing.T) {
+	export := &HierarchyExport{
+		Version:    1,
+		ExportedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		Root: &HierarchyNode{
+			Repository: "owner/repo",
+			Number:     1,
+			Title:      "Epic",
+			State:      "open",
+			Labels:     []string{"epic"},
+			SubIssues: []*HierarchyNode{
+				{Repository: "owner/repo", Number: 2, Title: "First", State: "closed", Assignees: []string{"alice"}},
+				{Repository: "other/repo", Number: 3, Title: "Second", State: "open", Milestone: "v1"},
+			},
+		},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			data, err := marshalHierarchy(export, format)
+			if err != nil {
+				t.Fatalf("marshalHierarchy() error = %v", err)
+			}
+
+			path := filepath.Join(t.TempDir(), "epic."+format)
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := readHierarchyFile(path)
+			if err != nil {
+				t.Fatalf("readHierarchyFile() error = %v", err)
+			}
+
+			if len(parsed.Root.SubIssues) != 2 {
+				t.Fatalf("got %d sub-issues, want 2", len(parsed.Root.SubIssues))
+			}
+			if parsed.Root.SubIssues[1].Repository != "other/repo" || parsed.Root.SubIssues[1].Milestone != "v1" {
+				t.Errorf("second sub-issue = %+v", parsed.Root.SubIssues[1])
+			}
+			if !parsed.ExportedAt.Equal(export.ExportedAt) {
+				t.Errorf("exportedAt = %v, want %v", parsed.ExportedAt, export.ExportedAt)
+			}
+		})
+	}
+}
+
+func TestMapValue(t *testing.T) {
+	mapping := map[string]string{"bug": "type: bug", "wontfix": ""}
+
+	if got, keep := mapValue(mapping, "bug"); got != "type: bug" || !keep {
+		t.Errorf("mapValue(bug) = %q, %v", got, keep)
+	}
+	if _, keep := mapValue(mapping, "wontfix"); keep {
+		t.Errorf("mapValue(wontfix) should drop the value")
+	}
+	if got, keep := mapValue(mapping, "docs"); got != "docs" || !keep {
+		t.Errorf("mapValue(docs) = %q, %v", got, keep)
+	}
+}
+
+// roundTripFunc stubs the HTTP transport of a GitHub client
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCheckAssignable(t *testing.T) {
+	statuses := map[string]int{"alice": http.StatusNoContent, "bob": http.StatusNotFound, "carol": http.StatusForbidden}
+	rest, err := api.NewRESTClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			login := path.Base(req.URL.Path)
+			return &http.Response{StatusCode: statuses[login], Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	creator := &hierarchyCreator{rest: rest, owner: "owner", repo: "repo", assignable: make(map[string]error), skipped: make(map[string]bool)}
+
+	node := &HierarchyNode{Title: "Ship", Assignees: []string{"alice", "bob", "carol"}}
+	opts := creator.issueOptions(node, true)
+	if !reflect.DeepEqual(opts.Assignees, []string{"alice"}) {
+		t.Errorf("assignees = %v, want [alice]", opts.Assignees)
+	}
+	if !creator.skipped["user @bob cannot be assigned in owner/repo"] {
+		t.Errorf("expected bob to be skipped as not assignable, got %v", creator.skipped)
+	}
+	if err := creator.assignable["carol"]; err == nil || errors.Is(err, errNotAssignable) {
+		t.Errorf("expected the 403 to be kept as an error, got %v", err)
+	}
+	if len(creator.skipped) != 2 {
+		t.Errorf("expected 2 skipped users, got %v", creator.skipped)
+	}
+}
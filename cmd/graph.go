@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// graphFormats are the --format values rendered as hierarchy graphs
+var graphFormats = map[string]bool{
+	"mermaid":  true,
+	"dot":      true,
+	"plantuml": true,
+}
+
+// graphNode is an issue drawn in a hierarchy graph
+type graphNode struct {
+	ID         string
+	Repository string
+	Number     int
+	Title      string
+	State      string
+	Assignees  []string
+	External   bool
+}
+
+// graphEdge connects two graph nodes by their IDs
+type graphEdge struct {
+	From string
+	To   string
+}
+
+// hierarchyGraph is the graph built from a hierarchy
+type hierarchyGraph struct {
+	Nodes        []graphNode
+	Edges        []graphEdge
+	Dependencies []graphEdge
+	Repositories []string
+}
+
+var graphIDPattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// graphNodeID returns an identifier that is valid in all graph languages
+func graphNodeID(repository string, number int) string {
+	return fmt.Sprintf("n_%s_%d", strings.Trim(graphIDPattern.ReplaceAllString(repository, "_"), "_"), number)
+}
+
+// buildHierarchyGraph flattens a hierarchy into nodes and edges.
+// Blocked-by dependencies become edges from the blocking to the blocked issue.
+func buildHierarchyGraph(root *HierarchyNode, dependencies bool) *hierarchyGraph {
+	graph := &hierarchyGraph{}
+	seen := make(map[string]bool)
+	repos := make(map[string]bool)
+
+	addNode := func(n graphNode) {
+		if seen[n.ID] {
+			return
+		}
+		seen[n.ID] = true
+		repos[n.Repository] = true
+		graph.Nodes = append(graph.Nodes, n)
+	}
+
+	fromHierarchy := func(node *HierarchyNode) graphNode {
+		return graphNode{
+			ID:         graphNodeID(node.Repository, node.Number),
+			Repository: node.Repository,
+			Number:     node.Number,
+			Title:      node.Title,
+			State:      node.State,
+			Assignees:  node.Assignees,
+		}
+	}
+
+	var deps []*HierarchyNode
+	addNode(fromHierarchy(root))
+	deps = append(deps, root)
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		addNode(fromHierarchy(node))
+		graph.Edges = append(graph.Edges, graphEdge{
+			From: graphNodeID(parent.Repository, parent.Number),
+			To:   graphNodeID(node.Repository, node.Number),
+		})
+		deps = append(deps, node)
+	})
+
+	if dependencies {
+		for _, node := range deps {
+			for _, blocker := range node.BlockedBy {
+				id := graphNodeID(blocker.Repository, blocker.Number)
+				addNode(graphNode{
+					ID:         id,
+					Repository: blocker.Repository,
+					Number:     blocker.Number,
+					Title:      blocker.Title,
+					State:      blocker.State,
+					External:   true,
+				})
+				graph.Dependencies = append(graph.Dependencies, graphEdge{
+					From: id,
+					To:   graphNodeID(node.Repository, node.Number),
+				})
+			}
+		}
+	}
+
+	for repo := range repos {
+		graph.Repositories = append(graph.Repositories, repo)
+	}
+	sort.Strings(graph.Repositories)
+	return graph
+}
+
+// graphNodeLines returns the label lines of a node
+func graphNodeLines(n graphNode) []string {
+	lines := []string{fmt.Sprintf("#%d %s", n.Number, n.Title)}
+	if len(n.Assignees) > 0 {
+		lines = append(lines, "@"+strings.Join(n.Assignees, ", @"))
+	}
+	return lines
+}
+
+// nodesByRepository returns the nodes of a repository in graph order
+func (g *hierarchyGraph) nodesByRepository(repo string) []graphNode {
+	var nodes []graphNode
+	for _, n := range g.Nodes {
+		if n.Repository == repo {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// formatMermaid renders a hierarchy graph as a Mermaid flowchart
+func formatMermaid(g *hierarchyGraph) string {
+	var output strings.Builder
+	output.WriteString("graph TD\n")
+
+	escape := func(s string) string {
+		return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+	}
+	writeNode := func(indent string, n graphNode) {
+		var lines []string
+		for _, line := range graphNodeLines(n) {
+			lines = append(lines, escape(line))
+		}
+		class := n.State
+		if n.External {
+			class += "_external"
+		}
+		output.WriteString(fmt.Sprintf("%s%s[\"%s\"]:::%s\n", indent, n.ID, strings.Join(lines, "<br/>"), class))
+	}
+
+	if len(g.Repositories) > 1 {
+		for i, repo := range g.Repositories {
+			output.WriteString(fmt.Sprintf("  subgraph repo%d[\"%s\"]\n", i, escape(repo)))
+			for _, n := range g.nodesByRepository(repo) {
+				writeNode("    ", n)
+			}
+			output.WriteString("  end\n")
+		}
+	} else {
+		for _, n := range g.Nodes {
+			writeNode("  ", n)
+		}
+	}
+
+	for _, e := range g.Edges {
+		output.WriteString(fmt.Sprintf("  %s --> %s\n", e.From, e.To))
+	}
+	for _, e := range g.Dependencies {
+		output.WriteString(fmt.Sprintf("  %s -. blocks .-> %s\n", e.From, e.To))
+	}
+
+	output.WriteString("  classDef open fill:#dafbe1,stroke:#1a7f37,color:#1f2328\n")
+	output.WriteString("  classDef closed fill:#fbefff,stroke:#8250df,color:#1f2328\n")
+	output.WriteString("  classDef open_external fill:#ffffff,stroke:#1a7f37,stroke-dasharray:4\n")
+	output.WriteString("  classDef closed_external fill:#ffffff,stroke:#8250df,stroke-dasharray:4\n")
+	return output.String()
+}
+
+// formatDOT renders a hierarchy graph in Graphviz DOT
+func formatDOT(g *hierarchyGraph) string {
+	var output strings.Builder
+	output.WriteString("digraph hierarchy {\n")
+	output.WriteString("  rankdir=TB;\n")
+	output.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	escape := func(s string) string {
+		return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+	}
+	writeNode := func(indent string, n graphNode) {
+		var lines []string
+		for _, line := range graphNodeLines(n) {
+			lines = append(lines, escape(line))
+		}
+		fill, stroke := "#dafbe1", "#1a7f37"
+		if n.State == "closed" {
+			fill, stroke = "#fbefff", "#8250df"
+		}
+		style := ""
+		if n.External {
+			fill = "#ffffff"
+			style = `, style="rounded,dashed,filled"`
+		}
+		output.WriteString(fmt.Sprintf("%s%s [label=\"%s\", fillcolor=\"%s\", color=\"%s\"%s];\n",
+			indent, n.ID, strings.Join(lines, `\n`), fill, stroke, style))
+	}
+
+	if len(g.Repositories) > 1 {
+		for i, repo := range g.Repositories {
+			output.WriteString(fmt.Sprintf("  subgraph cluster_%d {\n", i))
+			output.WriteString(fmt.Sprintf("    label=\"%s\";\n", escape(repo)))
+			for _, n := range g.nodesByRepository(repo) {
+				writeNode("    ", n)
+			}
+			output.WriteString("  }\n")
+		}
+	} else {
+		for _, n := range g.Nodes {
+			writeNode("  ", n)
+		}
+	}
+
+	for _, e := range g.Edges {
+		output.WriteString(fmt.Sprintf("  %s -> %s;\n", e.From, e.To))
+	}
+	for _, e := range g.Dependencies {
+		output.WriteString(fmt.Sprintf("  %s -> %s [style=dashed, label=\"blocks\"];\n", e.From, e.To))
+	}
+
+	output.WriteString("}\n")
+	return output.String()
+}
+
+// formatPlantUML renders a hierarchy graph as a PlantUML diagram
+func formatPlantUML(g *hierarchyGraph) string {
+	var output strings.Builder
+	output.WriteString("@startuml\n")
+	output.WriteString("skinparam rectangle {\n  RoundCorner 10\n}\n")
+
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, `"`, `'`)
+	}
+	writeNode := func(indent string, n graphNode) {
+		var lines []string
+		for _, line := range graphNodeLines(n) {
+			lines = append(lines, escape(line))
+		}
+		color := "#dafbe1;line:1a7f37"
+		if n.State == "closed" {
+			color = "#fbefff;line:8250df"
+		}
+		if n.External {
+			color += ";line.dashed"
+		}
+		output.WriteString(fmt.Sprintf("%srectangle \"%s\" as %s %s\n", indent, strings.Join(lines, `\n`), n.ID, color))
+	}
+
+	if len(g.Repositories) > 1 {
+		for _, repo := range g.Repositories {
+			output.WriteString(fmt.Sprintf("package \"%s\" {\n", escape(repo)))
+			for _, n := range g.nodesByRepository(repo) {
+				writeNode("  ", n)
+			}
+			output.WriteString("}\n")
+		}
+	} else {
+		for _, n := range g.Nodes {
+			writeNode("", n)
+		}
+	}
+
+	for _, e := range g.Edges {
+		output.WriteString(fmt.Sprintf("%s --> %s\n", e.From, e.To))
+	}
+	for _, e := range g.Dependencies {
+		output.WriteString(fmt.Sprintf("%s ..> %s : blocks\n", e.From, e.To))
+	}
+
+	output.WriteString("@enduml\n")
+	return output.String()
+}
+
+// formatGraph renders a hierarchy in the given graph format
+func formatGraph(root *HierarchyNode, format string, dependencies bool) (string, error) {
+	graph := buildHierarchyGraph(root, dependencies)
+	switch format {
+	case "mermaid":
+		return formatMermaid(graph), nil
+	case "dot":
+		return formatDOT(graph), nil
+	case "plantuml":
+		return formatPlantUML(graph), nil
+	}
+	return "", fmt.Errorf("unsupported graph format: %s", format)
+}
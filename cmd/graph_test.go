@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func graphTestHierarchy() *HierarchyNode {
+	return &HierarchyNode{
+		Repository: "owner/repo",
+		Number:     1,
+		Title:      "Epic",
+		State:      "open",
+		SubIssues: []*HierarchyNode{
+			{
+				Repository: "owner/repo",
+				Number:     2,
+				Title:      `Say "hi"`,
+				State:      "closed",
+				Assignees:  []string{"octocat"},
+			},
+			{
+				Repository: "other/project",
+				Number:     7,
+				Title:      "Other repo",
+				State:      "open",
+				BlockedBy: []IssueLink{
+					{Repository: "owner/repo", Number: 2, Title: `Say "hi"`, State: "closed"},
+					{Repository: "owner/repo", Number: 9, Title: "Outside", State: "open"},
+				},
+			},
+		},
+	}
+}
+
+func TestGraphNodeID(t *testing.T) {
+	tests := []struct {
+		repository string
+		number     int
+		expected   string
+	}{
+		{"owner/repo", 1, "n_owner_repo_1"},
+		{"my-org/my.repo", 42, "n_my_org_my_repo_42"},
+	}
+
+	for _, tt := range tests {
+		if got := graphNodeID(tt.repository, tt.number); got != tt.expected {
+			t.Errorf("graphNodeID(%q, %d) = %q, expected %q", tt.repository, tt.number, got, tt.expected)
+		}
+	}
+}
+
+func TestBuildHierarchyGraph(t *testing.T) {
+	graph := buildHierarchyGraph(graphTestHierarchy(), false)
+	if len(graph.Nodes) != 3 {
+		t.Errorf("expected 3 nodes, got %d", len(graph.Nodes))
+	}
+	if len(graph.Edges) != 2 {
+		t.Errorf("expected 2 edges, got %d", len(graph.Edges))
+	}
+	if len(graph.Dependencies) != 0 {
+		t.Errorf("expected no dependencies, got %d", len(graph.Dependencies))
+	}
+	if strings.Join(graph.Repositories, ",") != "other/project,owner/repo" {
+		t.Errorf("unexpected repositories: %v", graph.Repositories)
+	}
+
+	graph = buildHierarchyGraph(graphTestHierarchy(), true)
+	if len(graph.Nodes) != 4 {
+		t.Errorf("expected 4 nodes with external blocker, got %d", len(graph.Nodes))
+	}
+	if len(graph.Dependencies) != 2 {
+		t.Errorf("expected 2 dependencies, got %d", len(graph.Dependencies))
+	}
+	if !graph.Nodes[3].External {
+		t.Error("expected blocker outside the hierarchy to be external")
+	}
+}
+
+func TestFormatMermaid(t *testing.T) {
+	output := formatMermaid(buildHierarchyGraph(graphTestHierarchy(), true))
+
+	expected := []string{
+		"graph TD\n",
+		`subgraph repo0["other/project"]`,
+		`n_owner_repo_2["#2 Say #quot;hi#quot;<br/>@octocat"]:::closed`,
+		"n_owner_repo_1 --> n_other_project_7",
+		"n_owner_repo_2 -. blocks .-> n_other_project_7",
+		`n_owner_repo_9["#9 Outside"]:::open_external`,
+		"classDef closed fill:#fbefff",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("formatMermaid() missing %q\nGot:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatDOT(t *testing.T) {
+	output := formatDOT(buildHierarchyGraph(graphTestHierarchy(), true))
+
+	expected := []string{
+		"digraph hierarchy {\n",
+		"subgraph cluster_0 {",
+		`n_owner_repo_2 [label="#2 Say \"hi\"\n@octocat", fillcolor="#fbefff", color="#8250df"];`,
+		"n_owner_repo_1 -> n_owner_repo_2;",
+		`n_owner_repo_9 -> n_other_project_7 [style=dashed, label="blocks"];`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("formatDOT() missing %q\nGot:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatPlantUML(t *testing.T) {
+	single := &HierarchyNode{
+		Repository: "owner/repo",
+		Number:     1,
+		Title:      "Epic",
+		State:      "open",
+		SubIssues: []*HierarchyNode{
+			{Repository: "owner/repo", Number: 2, Title: "Task", State: "closed"},
+		},
+	}
+
+	expected := "@startuml\n" +
+		"skinparam rectangle {\n  RoundCorner 10\n}\n" +
+		`rectangle "#1 Epic" as n_owner_repo_1 #dafbe1;line:1a7f37` + "\n" +
+		`rectangle "#2 Task" as n_owner_repo_2 #fbefff;line:8250df` + "\n" +
+		"n_owner_repo_1 --> n_owner_repo_2\n" +
+		"@enduml\n"
+
+	output := formatPlantUML(buildHierarchyGraph(single, false))
+	if output != expected {
+		t.Errorf("formatPlantUML() output mismatch\nGot:\n%s\nExpected:\n%s", output, expected)
+	}
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// HierarchyNode represents an issue together with all of its sub-issues
+type HierarchyNode struct {
+	ID         string           `json:"id" yaml:"id"`
+	Repository string           `json:"repository" yaml:"repository"`
+	Number     int              `json:"number" yaml:"number"`
+	Title      string           `json:"title" yaml:"title"`
+	Body       string           `json:"body,omitempty" yaml:"body,omitempty"`
+	State      string           `json:"state" yaml:"state"`
+	URL        string           `json:"url" yaml:"url"`
+	Labels     []string         `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Assignees  []string         `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+	Milestone  string           `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+	IssueType  string           `json:"issueType,omitempty" yaml:"issueType,omitempty"`
+	BlockedBy  []IssueLink      `json:"blockedBy,omitempty" yaml:"blockedBy,omitempty"`
+	SubIssues  []*HierarchyNode `json:"subIssues,omitempty" yaml:"subIssues,omitempty"`
+
+	// IssueTypeID is the node ID of the issue type, needed to set it on other issues
+	IssueTypeID string `json:"-" yaml:"-"`
+
+	// MilestoneNumber is the number of the milestone, needed to restore it
+	MilestoneNumber int `json:"-" yaml:"-"`
+
+	// Archived reports whether the issue's repository is archived
+	Archived bool `json:"-" yaml:"-"`
+
+	// CreatedAt and ClosedAt are when the issue was opened and last closed
+	CreatedAt time.Time  `json:"-" yaml:"-"`
+	ClosedAt  *time.Time `json:"-" yaml:"-"`
+}
+
+// IssueLink is a reference to a related issue, such as a blocking issue
+type IssueLink struct {
+	ID         string   `json:"id" yaml:"id"`
+	Repository string   `json:"repository" yaml:"repository"`
+	Number     int      `json:"number" yaml:"number"`
+	Title      string   `json:"title" yaml:"title"`
+	State      string   `json:"state" yaml:"state"`
+	URL        string   `json:"url,omitempty" yaml:"url,omitempty"`
+	Assignees  []string `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+}
+
+// linkedIssueFields are the fields fetched for a related issue
+const linkedIssueFields = `
+	id
+	number
+	title
+	state
+	url
+	repository {
+		nameWithOwner
+	}`
+
+// hierarchyIssueFields are the issue fields fetched for every node of a hierarchy
+const hierarchyIssueFields = `
+	id
+	number
+	title
+	body
+	state
+	url
+	createdAt
+	closedAt
+	repository {
+		nameWithOwner
+		isArchived
+	}
+	labels(first: 50) {
+		nodes {
+			name
+		}
+	}
+	assignees(first: 10) {
+		nodes {
+			login
+		}
+	}
+	milestone {
+		number
+		title
+	}
+	issueType {
+		id
+		name
+	}
+	blockedBy(first: 20) {
+		nodes {` + linkedIssueFields + `
+		}
+	}
+	subIssuesSummary {
+		total
+	}`
+
+// hierarchyIssue is the GraphQL shape of hierarchyIssueFields
+type hierarchyIssue struct {
+	ID         string     `json:"id"`
+	Number     int        `json:"number"`
+	Title      string     `json:"title"`
+	Body       string     `json:"body"`
+	State      string     `json:"state"`
+	URL        string     `json:"url"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ClosedAt   *time.Time `json:"closedAt"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+		IsArchived    bool   `json:"isArchived"`
+	} `json:"repository"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"milestone"`
+	IssueType *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"issueType"`
+	BlockedBy struct {
+		Nodes []linkedIssue `json:"nodes"`
+	} `json:"blockedBy"`
+	SubIssuesSummary struct {
+		Total int `json:"total"`
+	} `json:"subIssuesSummary"`
+}
+
+// linkedIssue is the GraphQL shape of a related issue
+type linkedIssue struct {
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+}
+
+// toLink converts the GraphQL shape into an issue link
+func (i linkedIssue) toLink() IssueLink {
+	return IssueLink{
+		ID:         i.ID,
+		Repository: i.Repository.NameWithOwner,
+		Number:     i.Number,
+		Title:      i.Title,
+		State:      strings.ToLower(i.State),
+		URL:        i.URL,
+	}
+}
+
+// toNode converts the GraphQL shape into a hierarchy node without children
+func (i hierarchyIssue) toNode() *HierarchyNode {
+	node := &HierarchyNode{
+		ID:         i.ID,
+		Repository: i.Repository.NameWithOwner,
+		Number:     i.Number,
+		Title:      i.Title,
+		Body:       i.Body,
+		State:      strings.ToLower(i.State),
+		URL:        i.URL,
+		Archived:   i.Repository.IsArchived,
+		CreatedAt:  i.CreatedAt,
+		ClosedAt:   i.ClosedAt,
+	}
+	for _, label := range i.Labels.Nodes {
+		node.Labels = append(node.Labels, label.Name)
+	}
+	for _, assignee := range i.Assignees.Nodes {
+		node.Assignees = append(node.Assignees, assignee.Login)
+	}
+	if i.Milestone != nil {
+		node.Milestone = i.Milestone.Title
+		node.MilestoneNumber = i.Milestone.Number
+	}
+	if i.IssueType != nil {
+		node.IssueType = i.IssueType.Name
+		node.IssueTypeID = i.IssueType.ID
+	}
+	for _, blocker := range i.BlockedBy.Nodes {
+		node.BlockedBy = append(node.BlockedBy, blocker.toLink())
+	}
+	return node
+}
+
+// fetchHierarchy fetches an issue and its sub-issues recursively.
+// A maxDepth of 0 fetches the whole hierarchy.
+func fetchHierarchy(client *api.GraphQLClient, owner, repo string, number int, maxDepth int) (*HierarchyNode, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {` + hierarchyIssueFields + `
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue *hierarchyIssue `json:"issue"`
+		} `json:"repository"`
+	}
+
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	if response.Repository.Issue == nil {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
+	}
+
+	root := response.Repository.Issue.toNode()
+	if response.Repository.Issue.SubIssuesSummary.Total > 0 {
+		if err := fetchHierarchyChildren(client, root, 1, maxDepth, map[string]bool{root.ID: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remember the issues, so changes made to them are journaled without
+	// looking them up again
+	rememberIssues(nodeLink(root))
+	walkHierarchy(root, func(node, _ *HierarchyNode, _ int) {
+		rememberIssues(nodeLink(node))
+	})
+	return root, nil
+}
+
+// fetchHierarchyChildren populates the sub-issues of a node recursively
+func fetchHierarchyChildren(client *api.GraphQLClient, node *HierarchyNode, depth, maxDepth int, seen map[string]bool) error {
+	if maxDepth > 0 && depth > maxDepth {
+		return nil
+	}
+
+	query := `
+		query($id: ID!, $after: String) {
+			node(id: $id) {
+				... on Issue {
+					subIssues(first: 50, after: $after) {
+						nodes {` + hierarchyIssueFields + `
+						}
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":    node.ID,
+		"after": nil,
+	}
+
+	for {
+		var response struct {
+			Node struct {
+				SubIssues struct {
+					Nodes    []hierarchyIssue `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"subIssues"`
+			} `json:"node"`
+		}
+
+		if err := client.Do(query, variables, &response); err != nil {
+			return fmt.Errorf("failed to get sub-issues of #%d: %w", node.Number, err)
+		}
+
+		for _, issue := range response.Node.SubIssues.Nodes {
+			if issue.Number == 0 || seen[issue.ID] {
+				continue
+			}
+			seen[issue.ID] = true
+
+			child := issue.toNode()
+			node.SubIssues = append(node.SubIssues, child)
+
+			if issue.SubIssuesSummary.Total > 0 {
+				if err := fetchHierarchyChildren(client, child, depth+1, maxDepth, seen); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !response.Node.SubIssues.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Node.SubIssues.PageInfo.EndCursor
+	}
+
+	return nil
+}
+
+// walkHierarchy calls fn for every node below root in depth-first order
+func walkHierarchy(root *HierarchyNode, fn func(node, parent *HierarchyNode, depth int)) {
+	var walk func(node *HierarchyNode, depth int)
+	walk = func(node *HierarchyNode, depth int) {
+		for _, child := range node.SubIssues {
+			fn(child, node, depth)
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 1)
+}
+
+// splitRepository splits an OWNER/REPO string
+func splitRepository(nameWithOwner string) (string, string) {
+	parts := strings.SplitN(nameWithOwner, "/", 2)
+	if len(parts) != 2 {
+		return nameWithOwner, ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// IssueDetails represents an issue together with the fields needed to edit it
+type IssueDetails struct {
+	ID     string
+	Number int
+	Title  string
+	Body   string
+	State  string
+	URL    string
+}
+
+// CreateIssueOptions holds the fields for a new issue
+type CreateIssueOptions struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone int      `json:"milestone,omitempty"`
+}
+
+// CreatedIssue represents an issue returned by the create endpoint
+type CreatedIssue struct {
+	ID     string `json:"node_id"`
+	Number int    `json:"number"`
+	URL    string `json:"html_url"`
+}
+
+// getIssueDetails fetches an issue including its body
+func getIssueDetails(client *api.GraphQLClient, owner, repo string, number int) (*IssueDetails, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					id
+					number
+					title
+					body
+					state
+					url
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue struct {
+				ID     string `json:"id"`
+				Number int    `json:"number"`
+				Title  string `json:"title"`
+				Body   string `json:"body"`
+				State  string `json:"state"`
+				URL    string `json:"url"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+
+	issue := response.Repository.Issue
+	if issue.ID == "" {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
+	}
+
+	return &IssueDetails{
+		ID:     issue.ID,
+		Number: issue.Number,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		State:  strings.ToLower(issue.State),
+		URL:    issue.URL,
+	}, nil
+}
+
+// createIssue opens a new issue in the given repository
+func createIssue(owner, repo string, opts CreateIssueOptions) (*CreatedIssue, error) {
+	input := map[string]interface{}{"owner": owner, "repo": repo, "issue": opts}
+	if recordMutation("createIssue", input, fmt.Sprintf("Create issue %q in %s/%s", opts.Title, owner, repo)) {
+		return &CreatedIssue{ID: planNode(fmt.Sprintf("new issue %q", opts.Title))}, nil
+	}
+
+	client, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	payload, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue CreatedIssue
+	path := fmt.Sprintf("repos/%s/%s/issues", owner, repo)
+	if err := client.Post(path, bytes.NewReader(payload), &issue); err != nil {
+		return nil, fmt.Errorf("failed to create issue %q: %w", opts.Title, err)
+	}
+
+	link := IssueLink{ID: issue.ID, Repository: owner + "/" + repo, Number: issue.Number, Title: opts.Title, State: "open", URL: issue.URL}
+	rememberCreated(link)
+	writeJournal(JournalEntry{Action: journalCreate, Issue: link})
+
+	return &issue, nil
+}
+
+// updateIssueBody replaces the previous body of an issue
+func updateIssueBody(client *api.GraphQLClient, issueID, previous, body string) error {
+	mutation := `
+		mutation($id: ID!, $body: String!) {
+			updateIssue(input: {id: $id, body: $body}) {
+				issue {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":   issueID,
+		"body": body,
+	}
+	if recordMutation("updateIssueBody", variables, fmt.Sprintf("Update the body of %s", issueID), issueID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to update issue body: %w", err)
+	}
+	journalEdit(client, journalBody, issueID, []string{body}, &previous)
+
+	return nil
+}
+
+// setIssueState closes or reopens an issue
+func setIssueState(client *api.GraphQLClient, issueID, state string) error {
+	var mutation string
+	switch state {
+	case "closed":
+		mutation = `
+			mutation($id: ID!) {
+				closeIssue(input: {issueId: $id}) {
+					issue {
+						id
+					}
+				}
+			}`
+	case "open":
+		mutation = `
+			mutation($id: ID!) {
+				reopenIssue(input: {issueId: $id}) {
+					issue {
+						id
+					}
+				}
+			}`
+	default:
+		return fmt.Errorf("invalid issue state: %s", state)
+	}
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+	action, verb := "closeIssue", "Close"
+	if state == "open" {
+		action, verb = "reopenIssue", "Reopen"
+	}
+	if recordMutation(action, variables, fmt.Sprintf("%s %s", verb, issueID), issueID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to set issue state to %s: %w", state, err)
+	}
+	if state == "open" {
+		journalEdit(client, journalReopen, issueID, nil, nil)
+	} else {
+		journalEdit(client, journalClose, issueID, nil, nil)
+	}
+
+	return nil
+}
+
+// errMilestoneNotFound is wrapped by findMilestoneNumber when a repository has
+// no milestone with the title
+var errMilestoneNotFound = errors.New("not found")
+
+// findMilestoneNumber resolves a milestone title or number to its number
+func findMilestoneNumber(owner, repo, milestone string) (int, error) {
+	if number, err := strconv.Atoi(milestone); err == nil && number > 0 {
+		return number, nil
+	}
+
+	client, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	var milestones []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/milestones?state=all&per_page=100", owner, repo)
+	if err := client.Get(path, &milestones); err != nil {
+		return 0, fmt.Errorf("failed to get milestones: %w", err)
+	}
+
+	for _, m := range milestones {
+		if m.Title == milestone {
+			return m.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("milestone %q %w in %s/%s", milestone, errMilestoneNotFound, owner, repo)
+}
+
+// restRequest sends a JSON payload to the REST API
+func restRequest(method, path string, payload interface{}) error {
+	client, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	return client.Do(method, path, body, nil)
+}
+
+// restInput describes a REST change to an issue for a dry run
+func restInput(owner, repo string, number int, payload map[string]interface{}) map[string]interface{} {
+	input := map[string]interface{}{"owner": owner, "repo": repo, "number": number}
+	for key, value := range payload {
+		input[key] = value
+	}
+	return input
+}
+
+// setIssueMilestone replaces the previous milestone of an issue by number, or
+// clears it with 0
+func setIssueMilestone(owner, repo string, number, milestone, previous int) error {
+	payload := map[string]interface{}{"milestone": nil}
+	if milestone > 0 {
+		payload["milestone"] = milestone
+	}
+	description := fmt.Sprintf("Clear the milestone of %s/%s#%d", owner, repo, number)
+	if milestone > 0 {
+		description = fmt.Sprintf("Set the milestone of %s/%s#%d to milestone %d", owner, repo, number, milestone)
+	}
+	if recordMutation("setIssueMilestone", restInput(owner, repo, number, payload), description) {
+		return nil
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, number)
+	if err := restRequest("PATCH", path, payload); err != nil {
+		return fmt.Errorf("failed to set milestone of #%d: %w", number, err)
+	}
+	previousMilestone := strconv.Itoa(previous)
+	journalRESTEdit(journalMilestone, owner, repo, number, []string{strconv.Itoa(milestone)}, &previousMilestone)
+	return nil
+}
+
+// addIssueLabels adds labels to an issue
+func addIssueLabels(owner, repo string, number int, labels []string) error {
+	payload := map[string]interface{}{"labels": labels}
+	if recordMutation("addIssueLabels", restInput(owner, repo, number, payload),
+		fmt.Sprintf("Add labels %s to %s/%s#%d", joinSorted(labels), owner, repo, number)) {
+		return nil
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, number)
+	if err := restRequest("POST", path, payload); err != nil {
+		return fmt.Errorf("failed to add labels to #%d: %w", number, err)
+	}
+	journalRESTEdit(journalLabel, owner, repo, number, labels, nil)
+	return nil
+}
+
+// removeIssueLabel removes a label from an issue
+func removeIssueLabel(owner, repo string, number int, label string) error {
+	if recordMutation("removeIssueLabel", restInput(owner, repo, number, map[string]interface{}{"label": label}),
+		fmt.Sprintf("Remove label %s from %s/%s#%d", label, owner, repo, number)) {
+		return nil
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", owner, repo, number, url.PathEscape(label))
+	if err := restRequest("DELETE", path, nil); err != nil {
+		return fmt.Errorf("failed to remove label %q from #%d: %w", label, number, err)
+	}
+	journalRESTEdit(journalUnlabel, owner, repo, number, []string{label}, nil)
+	return nil
+}
+
+// addIssueAssignees adds assignees to an issue
+func addIssueAssignees(owner, repo string, number int, assignees []string) error {
+	payload := map[string]interface{}{"assignees": assignees}
+	if recordMutation("addIssueAssignees", restInput(owner, repo, number, payload),
+		fmt.Sprintf("Assign %s to %s/%s#%d", joinSorted(assignees), owner, repo, number)) {
+		return nil
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number)
+	if err := restRequest("POST", path, payload); err != nil {
+		return fmt.Errorf("failed to add assignees to #%d: %w", number, err)
+	}
+	journalRESTEdit(journalAssign, owner, repo, number, assignees, nil)
+	return nil
+}
+
+// removeIssueAssignees removes assignees from an issue
+func removeIssueAssignees(owner, repo string, number int, assignees []string) error {
+	payload := map[string]interface{}{"assignees": assignees}
+	if recordMutation("removeIssueAssignees", restInput(owner, repo, number, payload),
+		fmt.Sprintf("Unassign %s from %s/%s#%d", joinSorted(assignees), owner, repo, number)) {
+		return nil
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number)
+	if err := restRequest("DELETE", path, payload); err != nil {
+		return fmt.Errorf("failed to remove assignees from #%d: %w", number, err)
+	}
+	journalRESTEdit(journalUnassign, owner, repo, number, assignees, nil)
+	return nil
+}
+
+// setIssueType replaces the previous issue type of an issue
+func setIssueType(client *api.GraphQLClient, issueID, issueTypeID, previous string) error {
+	mutation := `
+		mutation($issueId: ID!, $issueTypeId: ID) {
+			updateIssueIssueType(input: {
+				issueId: $issueId,
+				issueTypeId: $issueTypeId
+			}) {
+				issue {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"issueId":     issueID,
+		"issueTypeId": nil,
+	}
+	if issueTypeID != "" {
+		variables["issueTypeId"] = issueTypeID
+	}
+	description := fmt.Sprintf("Clear the issue type of %s", issueID)
+	if issueTypeID != "" {
+		description = fmt.Sprintf("Set the issue type of %s to %s", issueID, issueTypeID)
+	}
+	if recordMutation("setIssueType", variables, description, issueID, issueTypeID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to set issue type: %w", err)
+	}
+	journalEdit(client, journalType, issueID, []string{issueTypeID}, &previous)
+	return nil
+}
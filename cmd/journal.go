@@ -0,0 +1,856 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/config"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimitFlag int
+	historyJSONFlag  bool
+	undoYesFlag      bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recent changes made by this extension",
+	Long: `Show the changes this extension made, newest first.
+
+Every command that changes sub-issues, issues, dependencies or project items
+is recorded as one operation in a journal in the gh config directory; in
+browse, every action is its own operation. Use the operation ID with
+"gh sub-issues undo" to reverse it.
+
+Examples:
+  # Show the last 10 operations
+  gh sub-issues history
+
+  # Show the last 50 operations as JSON
+  gh sub-issues history --limit 50 --json`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [<op-id>]",
+	Short: "Reverse an operation from the history",
+	Long: `Reverse the changes of an operation recorded in the history.
+
+Without an operation ID, the most recent operation that has not been undone
+is reversed. Added sub-issues are removed, removed sub-issues are added back
+at their original position, and moved sub-issues return to their previous
+parent. Created issues are closed, and other edits are reversed where their
+previous value was recorded. Changes that no longer match the current
+hierarchy are skipped.
+
+Examples:
+  # Undo the last operation
+  gh sub-issues undo
+
+  # Undo operation 12 without confirmation
+  gh sub-issues undo 12 --yes
+
+  # Show what undoing would change
+  gh sub-issues undo 12 --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUndo,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(undoCmd)
+
+	// Add flags
+	historyCmd.Flags().IntVarP(&historyLimitFlag, "limit", "L", 10, "Maximum number of operations to show")
+	historyCmd.Flags().BoolVar(&historyJSONFlag, "json", false, "Output in JSON format")
+	undoCmd.Flags().BoolVarP(&undoYesFlag, "yes", "y", false, "Undo without asking for confirmation")
+}
+
+// Journal actions for changes to sub-issues
+const (
+	journalAdd     = "add"
+	journalMove    = "move"
+	journalRemove  = "remove"
+	journalReorder = "reorder"
+)
+
+// Journal actions for changes to issues and their project items
+const (
+	journalCreate    = "create"
+	journalClose     = "close"
+	journalReopen    = "reopen"
+	journalBody      = "body"
+	journalMilestone = "milestone"
+	journalLabel     = "label"
+	journalUnlabel   = "unlabel"
+	journalAssign    = "assign"
+	journalUnassign  = "unassign"
+	journalType      = "type"
+	journalBlock     = "block"
+	journalUnblock   = "unblock"
+	journalProject   = "project"
+	journalUnproject = "unproject"
+	journalField     = "field"
+)
+
+// JournalEntry is one recorded change. Changes to sub-issues use Parent and
+// Child, changes to an issue itself use Issue.
+type JournalEntry struct {
+	Operation      int        `json:"op"`
+	Undoes         int        `json:"undoes,omitempty"`
+	Command        string     `json:"command"`
+	Time           time.Time  `json:"time"`
+	Action         string     `json:"action"`
+	Parent         IssueLink  `json:"parent,omitzero"`
+	Child          IssueLink  `json:"child,omitzero"`
+	PreviousParent *IssueLink `json:"previousParent,omitempty"`
+	PreviousAfter  string     `json:"previousAfter,omitempty"`
+	PreviousBefore string     `json:"previousBefore,omitempty"`
+
+	// Issue is the changed issue and Blocker the other end of a dependency
+	Issue   IssueLink `json:"issue,omitzero"`
+	Blocker IssueLink `json:"blocker,omitzero"`
+
+	// Project, Item and Field identify a changed project item and field
+	Project *ProjectSummary `json:"project,omitempty"`
+	Item    string          `json:"item,omitempty"`
+	Field   string          `json:"field,omitempty"`
+	FieldID string          `json:"fieldId,omitempty"`
+
+	// Values holds the labels, assignees or value set by the change, and
+	// Previous the value it replaced; nil when it is not known
+	Values   []string `json:"values,omitempty"`
+	Previous *string  `json:"previous,omitempty"`
+}
+
+// JournalOperation groups the entries recorded by one command
+type JournalOperation struct {
+	ID       int            `json:"id"`
+	Command  string         `json:"command"`
+	Time     time.Time      `json:"time"`
+	Undoes   int            `json:"undoes,omitempty"`
+	UndoneBy int            `json:"undoneBy,omitempty"`
+	Changes  []JournalEntry `json:"changes"`
+}
+
+// journal numbers the operation of this run and serializes writes. It also
+// keeps the issues seen in this run, so entries can name them without looking
+// them up again, and which issues and project items this run created.
+var journal struct {
+	mu        sync.Mutex
+	operation int
+	undoes    int
+	links     map[string]IssueLink
+	created   map[string]bool
+	items     map[string]bool
+}
+
+// startJournalOperation makes the following changes a new operation, for
+// commands such as browse that make several independent changes in one run
+func startJournalOperation() {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	journal.operation = 0
+}
+
+// journalPath returns the file the journal is kept in
+func journalPath() string {
+	return filepath.Join(config.ConfigDir(), "sub-issues", "journal.jsonl")
+}
+
+// readJournal reads all entries of a journal; a missing journal is empty
+func readJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to read journal %s line %d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// appendJournal adds an entry to the journal, under the operation of this run
+func appendJournal(path string, entry JournalEntry) error {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	if journal.operation == 0 {
+		entries, err := readJournal(path)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			journal.operation = max(journal.operation, e.Operation)
+		}
+		journal.operation++
+	}
+	entry.Operation = journal.operation
+	entry.Undoes = journal.undoes
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// issuePosition is an issue with its parent and neighbouring sub-issues
+type issuePosition struct {
+	Issue  IssueLink
+	Parent *IssueLink
+	After  string
+	Before string
+}
+
+// getIssuePositions looks up issues with their current parent and position
+func getIssuePositions(client *api.GraphQLClient, ids ...string) (map[string]issuePosition, error) {
+	query := `
+		query($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				... on Issue {` + linkedIssueFields + `
+					parent {` + linkedIssueFields + `
+						subIssues(first: 100) {
+							nodes {
+								id
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	var response struct {
+		Nodes []struct {
+			linkedIssue
+			Parent *struct {
+				linkedIssue
+				SubIssues struct {
+					Nodes []struct {
+						ID string `json:"id"`
+					} `json:"nodes"`
+				} `json:"subIssues"`
+			} `json:"parent"`
+		} `json:"nodes"`
+	}
+	if err := client.Do(query, map[string]interface{}{"ids": ids}, &response); err != nil {
+		return nil, fmt.Errorf("failed to get issue positions: %w", err)
+	}
+
+	positions := make(map[string]issuePosition, len(ids))
+	for _, node := range response.Nodes {
+		position := issuePosition{Issue: node.toLink()}
+		if node.Parent != nil {
+			parent := node.Parent.toLink()
+			position.Parent = &parent
+			var siblings []string
+			for _, sibling := range node.Parent.SubIssues.Nodes {
+				siblings = append(siblings, sibling.ID)
+			}
+			position.After, position.Before = neighbours(siblings, node.ID)
+		}
+		positions[node.ID] = position
+	}
+	return positions, nil
+}
+
+// neighbours returns the IDs before and after an ID in a list
+func neighbours(ids []string, id string) (string, string) {
+	for i := range ids {
+		if ids[i] != id {
+			continue
+		}
+		after, before := "", ""
+		if i > 0 {
+			after = ids[i-1]
+		}
+		if i < len(ids)-1 {
+			before = ids[i+1]
+		}
+		return after, before
+	}
+	return "", ""
+}
+
+// writeJournal records a change made by this run. Failing to record is only a
+// warning, as the change has already been made.
+func writeJournal(entry JournalEntry) {
+	entry.Command = strings.Join(os.Args[1:], " ")
+	entry.Time = time.Now()
+	if err := appendJournal(journalPath(), entry); err != nil {
+		fmt.Fprintf(os.Stderr, "! Failed to record the change in the journal: %v\n", err)
+	}
+}
+
+// journalChange records a change to the sub-issues of a parent, given the
+// positions looked up before the change
+func journalChange(action string, positions map[string]issuePosition, parentID, subIssueID string) {
+	child := positions[subIssueID]
+	writeJournal(JournalEntry{
+		Action:         action,
+		Parent:         positions[parentID].Issue,
+		Child:          child.Issue,
+		PreviousParent: child.Parent,
+		PreviousAfter:  child.After,
+		PreviousBefore: child.Before,
+	})
+}
+
+// rememberIssues keeps issues seen in this run for later journal entries
+func rememberIssues(links ...IssueLink) {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	if journal.links == nil {
+		journal.links = make(map[string]IssueLink)
+	}
+	for _, link := range links {
+		if link.ID != "" {
+			link.Assignees = nil
+			journal.links[link.ID] = link
+		}
+	}
+}
+
+// rememberCreated marks an issue as created by this run
+func rememberCreated(link IssueLink) {
+	rememberIssues(link)
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	if journal.created == nil {
+		journal.created = make(map[string]bool)
+	}
+	journal.created[link.ID] = true
+}
+
+// createdInRun reports whether an issue was created by this run
+func createdInRun(id string) bool {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	return journal.created[id]
+}
+
+// rememberedIssues returns the issues seen in this run by ID. Issues not seen
+// are identified by their ID only and returned as unknown.
+func rememberedIssues(ids ...string) (map[string]IssueLink, []string) {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	links := make(map[string]IssueLink, len(ids))
+	var unknown []string
+	for _, id := range ids {
+		link, ok := journal.links[id]
+		if !ok {
+			link = IssueLink{ID: id}
+			unknown = append(unknown, id)
+		}
+		links[id] = link
+	}
+	return links, unknown
+}
+
+// journalIssues returns issues by ID for journal entries, looking up those not
+// seen in this run. A failed lookup is only a warning.
+func journalIssues(client *api.GraphQLClient, ids ...string) map[string]IssueLink {
+	links, unknown := rememberedIssues(ids...)
+	if len(unknown) == 0 {
+		return links
+	}
+
+	query := `
+		query($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				... on Issue {` + linkedIssueFields + `
+				}
+			}
+		}`
+
+	var response struct {
+		Nodes []linkedIssue `json:"nodes"`
+	}
+	if err := client.Do(query, map[string]interface{}{"ids": unknown}, &response); err != nil {
+		fmt.Fprintf(os.Stderr, "! Failed to look up issues for the journal: %v\n", err)
+		return links
+	}
+	for _, node := range response.Nodes {
+		if node.ID != "" {
+			links[node.ID] = node.toLink()
+			rememberIssues(node.toLink())
+		}
+	}
+	return links
+}
+
+// journalPositions looks up the positions of a parent and a sub-issue before a
+// change to them. Issues created by this run have no position worth restoring,
+// so only their links are needed. A failed lookup is only a warning; the entry
+// then lacks the previous position.
+func journalPositions(client *api.GraphQLClient, parentID, subIssueID string) map[string]issuePosition {
+	positions := make(map[string]issuePosition, 2)
+	if createdInRun(subIssueID) {
+		for id, link := range journalIssues(client, parentID, subIssueID) {
+			positions[id] = issuePosition{Issue: link}
+		}
+		return positions
+	}
+
+	looked, err := getIssuePositions(client, parentID, subIssueID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "! Failed to look up issues for the journal: %v\n", err)
+		links, _ := rememberedIssues(parentID, subIssueID)
+		for id, link := range links {
+			positions[id] = issuePosition{Issue: link}
+		}
+		return positions
+	}
+	for id, position := range looked {
+		rememberIssues(position.Issue)
+		positions[id] = position
+	}
+	return positions
+}
+
+// journalEdit records a change to an issue itself
+func journalEdit(client *api.GraphQLClient, action, issueID string, values []string, previous *string) {
+	writeJournal(JournalEntry{
+		Action:   action,
+		Issue:    journalIssues(client, issueID)[issueID],
+		Values:   values,
+		Previous: previous,
+	})
+}
+
+// journalRESTEdit records a change to an issue made through the REST API, which
+// identifies issues by number rather than ID
+func journalRESTEdit(action, owner, repo string, number int, values []string, previous *string) {
+	writeJournal(JournalEntry{
+		Action:   action,
+		Issue:    IssueLink{Repository: owner + "/" + repo, Number: number},
+		Values:   values,
+		Previous: previous,
+	})
+}
+
+// groupOperations groups journal entries into operations, oldest first
+func groupOperations(entries []JournalEntry) []*JournalOperation {
+	var operations []*JournalOperation
+	byID := make(map[int]*JournalOperation)
+	for _, entry := range entries {
+		operation, ok := byID[entry.Operation]
+		if !ok {
+			operation = &JournalOperation{
+				ID:      entry.Operation,
+				Command: entry.Command,
+				Time:    entry.Time,
+				Undoes:  entry.Undoes,
+			}
+			byID[entry.Operation] = operation
+			operations = append(operations, operation)
+		}
+		operation.Changes = append(operation.Changes, entry)
+	}
+
+	for _, operation := range operations {
+		if target, ok := byID[operation.Undoes]; ok && operation.Undoes != 0 {
+			target.UndoneBy = operation.ID
+		}
+	}
+	return operations
+}
+
+// findUndoTarget picks the operation to undo: the given ID, or the latest
+// operation that is neither an undo nor undone
+func findUndoTarget(operations []*JournalOperation, id int) (*JournalOperation, error) {
+	if id == 0 {
+		for i := len(operations) - 1; i >= 0; i-- {
+			if operations[i].Undoes == 0 && operations[i].UndoneBy == 0 {
+				return operations[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no operation to undo")
+	}
+
+	for _, operation := range operations {
+		if operation.ID != id {
+			continue
+		}
+		if operation.UndoneBy != 0 {
+			return nil, fmt.Errorf("operation %d was already undone by operation %d", id, operation.UndoneBy)
+		}
+		return operation, nil
+	}
+	return nil, fmt.Errorf("operation %d not found in the history", id)
+}
+
+// describeJournalEntry describes a recorded change
+func describeJournalEntry(entry JournalEntry) string {
+	child, parent := linkRef(entry.Child, ""), linkRef(entry.Parent, "")
+	issue, values := linkRef(entry.Issue, ""), strings.Join(entry.Values, ", ")
+	switch entry.Action {
+	case journalAdd:
+		return fmt.Sprintf("Added %s to %s", child, parent)
+	case journalMove:
+		return fmt.Sprintf("Moved %s from %s to %s", child, linkRef(*entry.PreviousParent, ""), parent)
+	case journalRemove:
+		return fmt.Sprintf("Removed %s from %s", child, parent)
+	case journalReorder:
+		return fmt.Sprintf("Reordered %s in %s", child, parent)
+	case journalCreate:
+		return fmt.Sprintf("Created %s", issue)
+	case journalClose:
+		return fmt.Sprintf("Closed %s", issue)
+	case journalReopen:
+		return fmt.Sprintf("Reopened %s", issue)
+	case journalBody:
+		return fmt.Sprintf("Updated the body of %s", issue)
+	case journalMilestone:
+		return fmt.Sprintf("Changed the milestone of %s", issue)
+	case journalLabel:
+		return fmt.Sprintf("Added labels %s to %s", values, issue)
+	case journalUnlabel:
+		return fmt.Sprintf("Removed labels %s from %s", values, issue)
+	case journalAssign:
+		return fmt.Sprintf("Assigned %s to %s", values, issue)
+	case journalUnassign:
+		return fmt.Sprintf("Unassigned %s from %s", values, issue)
+	case journalType:
+		return fmt.Sprintf("Changed the issue type of %s", issue)
+	case journalBlock:
+		return fmt.Sprintf("Marked %s as blocked by %s", issue, linkRef(entry.Blocker, ""))
+	case journalUnblock:
+		return fmt.Sprintf("Removed the dependency of %s on %s", issue, linkRef(entry.Blocker, ""))
+	case journalProject:
+		return fmt.Sprintf("Added %s to project %s", issue, entry.Project.Title)
+	case journalUnproject:
+		return fmt.Sprintf("Removed %s from project %s", issue, entry.Project.Title)
+	case journalField:
+		if len(entry.Values) == 0 {
+			return fmt.Sprintf("Cleared %s of a project item", entry.Field)
+		}
+		return fmt.Sprintf("Set %s of a project item to %q", entry.Field, values)
+	}
+	return fmt.Sprintf("%s %s in %s", entry.Action, child, parent)
+}
+
+// describeUndo describes the change that reverses a recorded change
+func describeUndo(entry JournalEntry) string {
+	child, parent := linkRef(entry.Child, ""), linkRef(entry.Parent, "")
+	issue, values := linkRef(entry.Issue, ""), strings.Join(entry.Values, ", ")
+	switch entry.Action {
+	case journalAdd:
+		return fmt.Sprintf("Remove %s from %s", child, parent)
+	case journalMove:
+		return fmt.Sprintf("Move %s back from %s to %s", child, parent, linkRef(*entry.PreviousParent, ""))
+	case journalRemove:
+		return fmt.Sprintf("Add %s back to %s", child, parent)
+	case journalReorder:
+		return fmt.Sprintf("Move %s back to its previous position in %s", child, parent)
+	case journalCreate, journalReopen:
+		return fmt.Sprintf("Close %s", issue)
+	case journalClose:
+		return fmt.Sprintf("Reopen %s", issue)
+	case journalBody:
+		return fmt.Sprintf("Restore the body of %s", issue)
+	case journalMilestone:
+		return fmt.Sprintf("Restore the milestone of %s", issue)
+	case journalLabel:
+		return fmt.Sprintf("Remove labels %s from %s", values, issue)
+	case journalUnlabel:
+		return fmt.Sprintf("Add labels %s back to %s", values, issue)
+	case journalAssign:
+		return fmt.Sprintf("Unassign %s from %s", values, issue)
+	case journalUnassign:
+		return fmt.Sprintf("Assign %s back to %s", values, issue)
+	case journalType:
+		return fmt.Sprintf("Restore the issue type of %s", issue)
+	case journalBlock:
+		return fmt.Sprintf("Remove the dependency of %s on %s", issue, linkRef(entry.Blocker, ""))
+	case journalUnblock:
+		return fmt.Sprintf("Mark %s as blocked by %s again", issue, linkRef(entry.Blocker, ""))
+	case journalProject:
+		return fmt.Sprintf("Remove %s from project %s", issue, entry.Project.Title)
+	case journalUnproject:
+		return fmt.Sprintf("Add %s back to project %s", issue, entry.Project.Title)
+	case journalField:
+		return fmt.Sprintf("Restore %s of a project item", entry.Field)
+	}
+	return fmt.Sprintf("Reverse %s %s", entry.Action, child)
+}
+
+// formatHistory lists operations, newest first
+func formatHistory(operations []*JournalOperation) string {
+	if len(operations) == 0 {
+		return "No changes recorded\n"
+	}
+
+	var output strings.Builder
+	for i := len(operations) - 1; i >= 0; i-- {
+		operation := operations[i]
+		line := fmt.Sprintf("%d  %s  %s", operation.ID, operation.Time.Local().Format("2006-01-02 15:04:05"), operation.Command)
+		if operation.UndoneBy != 0 {
+			line += fmt.Sprintf("  (undone by %d)", operation.UndoneBy)
+		}
+		output.WriteString(line + "\n")
+		for _, change := range operation.Changes {
+			output.WriteString("    " + describeJournalEntry(change) + "\n")
+		}
+	}
+	return output.String()
+}
+
+// runHistory shows the most recent operations of the journal
+func runHistory(cmd *cobra.Command, args []string) error {
+	entries, err := readJournal(journalPath())
+	if err != nil {
+		return err
+	}
+	operations := groupOperations(entries)
+	if historyLimitFlag > 0 && len(operations) > historyLimitFlag {
+		operations = operations[len(operations)-historyLimitFlag:]
+	}
+
+	if historyJSONFlag {
+		newestFirst := make([]*JournalOperation, 0, len(operations))
+		for i := len(operations) - 1; i >= 0; i-- {
+			newestFirst = append(newestFirst, operations[i])
+		}
+		data, err := json.MarshalIndent(newestFirst, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), formatHistory(operations))
+	return nil
+}
+
+// runUndo reverses the changes of an operation, newest change first
+func runUndo(cmd *cobra.Command, args []string) error {
+	id := 0
+	if len(args) == 1 {
+		var err error
+		id, err = strconv.Atoi(args[0])
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid operation ID: %s", args[0])
+		}
+	}
+
+	entries, err := readJournal(journalPath())
+	if err != nil {
+		return err
+	}
+	target, err := findUndoTarget(groupOperations(entries), id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Operation %d: %s\n", target.ID, target.Command)
+	for i := len(target.Changes) - 1; i >= 0; i-- {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", describeUndo(target.Changes[i]))
+	}
+
+	if !undoYesFlag && dryRun == nil {
+		if !term.IsTerminal(os.Stdin) {
+			return fmt.Errorf("refusing to undo without confirmation (use --yes)")
+		}
+		ok, err := confirm(cmd, fmt.Sprintf("Undo operation %d?", target.ID))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("undo cancelled")
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	journal.mu.Lock()
+	journal.undoes = target.ID
+	journal.mu.Unlock()
+
+	failed := 0
+	for i := len(target.Changes) - 1; i >= 0; i-- {
+		entry := target.Changes[i]
+		skipped, err := undoJournalEntry(client, entry)
+		switch {
+		case err != nil:
+			fmt.Fprintf(cmd.ErrOrStderr(), "✗ %s: %v\n", describeUndo(entry), err)
+			failed++
+		case skipped != "":
+			fmt.Fprintf(cmd.ErrOrStderr(), "! Skipped: %s (%s)\n", describeUndo(entry), skipped)
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ %s\n", describeUndo(entry))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to undo %d of %d changes", failed, len(target.Changes))
+	}
+	return nil
+}
+
+// undoJournalEntry applies the inverse of a recorded change. It returns a reason
+// when the change no longer matches the current state and was skipped.
+func undoJournalEntry(client *api.GraphQLClient, entry JournalEntry) (string, error) {
+	switch entry.Action {
+	case journalAdd, journalMove, journalRemove, journalReorder:
+		return undoLinkChange(client, entry)
+	}
+	return undoEdit(client, entry)
+}
+
+// undoLinkChange reverses a change to the sub-issues of a parent
+func undoLinkChange(client *api.GraphQLClient, entry JournalEntry) (string, error) {
+	positions, err := getIssuePositions(client, entry.Child.ID)
+	if err != nil {
+		return "", err
+	}
+	child := linkRef(entry.Child, "")
+	current := positions[entry.Child.ID].Parent
+
+	if entry.Action == journalRemove {
+		if current != nil {
+			return fmt.Sprintf("%s is now a sub-issue of %s", child, linkRef(*current, "")), nil
+		}
+		if _, _, err := addSubIssue(client, entry.Parent.ID, entry.Child.ID, false); err != nil {
+			return "", err
+		}
+		return "", restorePosition(client, entry.Parent.ID, entry)
+	}
+
+	if current == nil || current.ID != entry.Parent.ID {
+		return fmt.Sprintf("%s is no longer a sub-issue of %s", child, linkRef(entry.Parent, "")), nil
+	}
+
+	switch entry.Action {
+	case journalAdd:
+		return "", removeSubIssue(client, entry.Parent.ID, entry.Child.ID)
+	case journalMove:
+		if _, _, err := addSubIssue(client, entry.PreviousParent.ID, entry.Child.ID, true); err != nil {
+			return "", err
+		}
+		return "", restorePosition(client, entry.PreviousParent.ID, entry)
+	case journalReorder:
+		return "", restorePosition(client, entry.Parent.ID, entry)
+	}
+	return "", fmt.Errorf("unknown action %q", entry.Action)
+}
+
+// undoEdit reverses a change to an issue or its project item. Issues cannot be
+// deleted, so created issues are closed instead.
+func undoEdit(client *api.GraphQLClient, entry JournalEntry) (string, error) {
+	owner, repo := splitRepository(entry.Issue.Repository)
+	number := entry.Issue.Number
+
+	switch entry.Action {
+	case journalCreate, journalReopen, journalClose:
+		state := "closed"
+		if entry.Action == journalClose {
+			state = "open"
+		}
+		positions, err := getIssuePositions(client, entry.Issue.ID)
+		if err != nil {
+			return "", err
+		}
+		if positions[entry.Issue.ID].Issue.State == state {
+			return fmt.Sprintf("%s is already %s", linkRef(entry.Issue, ""), state), nil
+		}
+		return "", setIssueState(client, entry.Issue.ID, state)
+	case journalLabel:
+		for _, label := range entry.Values {
+			if err := removeIssueLabel(owner, repo, number, label); err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+	case journalUnlabel:
+		return "", addIssueLabels(owner, repo, number, entry.Values)
+	case journalAssign:
+		return "", removeIssueAssignees(owner, repo, number, entry.Values)
+	case journalUnassign:
+		return "", addIssueAssignees(owner, repo, number, entry.Values)
+	case journalBlock:
+		return "", removeBlockedBy(client, entry.Issue.ID, entry.Blocker.ID)
+	case journalUnblock:
+		return "", addBlockedBy(client, entry.Issue.ID, entry.Blocker.ID)
+	case journalProject:
+		return "", deleteProjectItem(client, *entry.Project, entry.Item, entry.Issue.ID)
+	case journalUnproject:
+		_, err := addProjectItem(client, *entry.Project, entry.Issue.ID)
+		return "", err
+	}
+
+	if entry.Previous == nil {
+		return "the previous value was not recorded", nil
+	}
+	current := strings.Join(entry.Values, "")
+	switch entry.Action {
+	case journalBody:
+		return "", updateIssueBody(client, entry.Issue.ID, current, *entry.Previous)
+	case journalMilestone:
+		milestone, _ := strconv.Atoi(*entry.Previous)
+		previous, _ := strconv.Atoi(current)
+		return "", setIssueMilestone(owner, repo, number, milestone, previous)
+	case journalType:
+		return "", setIssueType(client, entry.Issue.ID, *entry.Previous, current)
+	case journalField:
+		return "", clearProjectItemField(client, entry.Project.ID, entry.Item, entry.FieldID, entry.Field)
+	}
+	return "", fmt.Errorf("unknown action %q", entry.Action)
+}
+
+// restorePosition moves a sub-issue back next to its previous neighbours
+func restorePosition(client *api.GraphQLClient, parentID string, entry JournalEntry) error {
+	switch {
+	case entry.PreviousAfter != "":
+		return reprioritizeSubIssue(client, parentID, entry.Child.ID, entry.PreviousAfter, "")
+	case entry.PreviousBefore != "":
+		return reprioritizeSubIssue(client, parentID, entry.Child.ID, "", entry.PreviousBefore)
+	}
+	return nil
+}
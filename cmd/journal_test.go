@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNeighbours(t *testing.T) {
+	tests := []struct {
+		id, after, before string
+	}{
+		{"a", "", "b"},
+		{"b", "a", "c"},
+		{"c", "b", ""},
+		{"x", "", ""},
+	}
+	for _, tt := range tests {
+		after, before := neighbours([]string{"a", "b", "c"}, tt.id)
+		if after != tt.after || before != tt.before {
+			t.Errorf("neighbours(%q) = %q, %q; want %q, %q", tt.id, after, before, tt.after, tt.before)
+		}
+	}
+}
+
+func TestAppendJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub-issues", "journal.jsonl")
+	defer func() { journal.operation, journal.undoes = 0, 0 }()
+
+	parent := IssueLink{ID: "I_1", Repository: "owner/repo", Number: 1}
+	for _, number := range []int{2, 3} {
+		entry := JournalEntry{Action: journalAdd, Parent: parent, Child: IssueLink{Repository: "owner/repo", Number: number}}
+		if err := appendJournal(path, entry); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// A later run continues the numbering and records what it undoes
+	journal.operation, journal.undoes = 0, 1
+	if err := appendJournal(path, JournalEntry{Action: journalRemove, Parent: parent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each browse action starts a new operation within the same run
+	journal.undoes = 0
+	startJournalOperation()
+	if err := appendJournal(path, JournalEntry{Action: journalClose, Issue: parent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := readJournal(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+	if entries[0].Operation != 1 || entries[1].Operation != 1 || entries[2].Operation != 2 || entries[2].Undoes != 1 || entries[3].Operation != 3 {
+		t.Errorf("unexpected operations %+v", entries)
+	}
+	if entries[1].Child.Number != 3 || entries[1].Parent.ID != "I_1" {
+		t.Errorf("unexpected entry %+v", entries[1])
+	}
+
+	missing, err := readJournal(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if err != nil || missing != nil {
+		t.Errorf("readJournal(missing) = %v, %v", missing, err)
+	}
+}
+
+func TestFindUndoTarget(t *testing.T) {
+	operations := groupOperations([]JournalEntry{
+		{Operation: 1, Action: journalAdd},
+		{Operation: 2, Action: journalAdd},
+		{Operation: 2, Action: journalAdd},
+		{Operation: 3, Action: journalRemove, Undoes: 2},
+	})
+	if len(operations) != 3 || len(operations[1].Changes) != 2 || operations[1].UndoneBy != 3 {
+		t.Fatalf("unexpected operations %+v", operations)
+	}
+
+	target, err := findUndoTarget(operations, 0)
+	if err != nil || target.ID != 1 {
+		t.Errorf("default target = %+v, %v; want operation 1", target, err)
+	}
+	if target, err := findUndoTarget(operations, 3); err != nil || target.ID != 3 {
+		t.Errorf("undoing an undo = %+v, %v", target, err)
+	}
+	if _, err := findUndoTarget(operations, 2); err == nil || !strings.Contains(err.Error(), "already undone by operation 3") {
+		t.Errorf("expected already undone error, got %v", err)
+	}
+	if _, err := findUndoTarget(operations, 9); err == nil {
+		t.Error("expected error for unknown operation")
+	}
+	if _, err := findUndoTarget(nil, 0); err == nil {
+		t.Error("expected error for empty history")
+	}
+}
+
+func TestFormatHistory(t *testing.T) {
+	parent := IssueLink{Repository: "owner/repo", Number: 1}
+	previous := IssueLink{Repository: "owner/repo", Number: 9}
+	child := IssueLink{Repository: "owner/other", Number: 2}
+	when := time.Date(2026, 10, 18, 15, 30, 0, 0, time.Local)
+
+	operations := groupOperations([]JournalEntry{
+		{Operation: 1, Command: "add 1 2 --replace-parent", Time: when, Action: journalMove, Parent: parent, Child: child, PreviousParent: &previous},
+		{Operation: 2, Command: "undo", Time: when.Add(time.Minute), Undoes: 1, Action: journalMove, Parent: previous, Child: child, PreviousParent: &parent},
+	})
+
+	want := "2  2026-10-18 15:31:00  undo\n" +
+		"    Moved owner/other#2 from owner/repo#1 to owner/repo#9\n" +
+		"1  2026-10-18 15:30:00  add 1 2 --replace-parent  (undone by 2)\n" +
+		"    Moved owner/other#2 from owner/repo#9 to owner/repo#1\n"
+	if got := formatHistory(operations); got != want {
+		t.Errorf("formatHistory() =\n%s\nwant:\n%s", got, want)
+	}
+	if got := formatHistory(nil); got != "No changes recorded\n" {
+		t.Errorf("formatHistory(nil) = %q", got)
+	}
+
+	if got := describeUndo(operations[0].Changes[0]); got != "Move owner/other#2 back from owner/repo#1 to owner/repo#9" {
+		t.Errorf("describeUndo() = %q", got)
+	}
+}
+
+func TestDescribeEdits(t *testing.T) {
+	issue := IssueLink{ID: "I_5", Repository: "owner/repo", Number: 5}
+	blocker := IssueLink{ID: "I_6", Repository: "owner/repo", Number: 6}
+	project := &ProjectSummary{ID: "P_1", Title: "Roadmap"}
+
+	tests := []struct {
+		entry      JournalEntry
+		change     string
+		undoChange string
+	}{
+		{JournalEntry{Action: journalCreate, Issue: issue}, "Created owner/repo#5", "Close owner/repo#5"},
+		{JournalEntry{Action: journalClose, Issue: issue}, "Closed owner/repo#5", "Reopen owner/repo#5"},
+		{JournalEntry{Action: journalLabel, Issue: issue, Values: []string{"bug", "p1"}}, "Added labels bug, p1 to owner/repo#5", "Remove labels bug, p1 from owner/repo#5"},
+		{JournalEntry{Action: journalAssign, Issue: issue, Values: []string{"octocat"}}, "Assigned octocat to owner/repo#5", "Unassign octocat from owner/repo#5"},
+		{JournalEntry{Action: journalBlock, Issue: issue, Blocker: blocker}, "Marked owner/repo#5 as blocked by owner/repo#6", "Remove the dependency of owner/repo#5 on owner/repo#6"},
+		{JournalEntry{Action: journalProject, Issue: issue, Project: project}, "Added owner/repo#5 to project Roadmap", "Remove owner/repo#5 from project Roadmap"},
+		{JournalEntry{Action: journalField, Project: project, Field: "Status", Values: []string{"Todo"}}, `Set Status of a project item to "Todo"`, "Restore Status of a project item"},
+	}
+	for _, tt := range tests {
+		if got := describeJournalEntry(tt.entry); got != tt.change {
+			t.Errorf("describeJournalEntry(%s) = %q, want %q", tt.entry.Action, got, tt.change)
+		}
+		if got := describeUndo(tt.entry); got != tt.undoChange {
+			t.Errorf("describeUndo(%s) = %q, want %q", tt.entry.Action, got, tt.undoChange)
+		}
+	}
+}
+
+func TestUndoEditWithoutPreviousValue(t *testing.T) {
+	entry := JournalEntry{Action: journalBody, Issue: IssueLink{ID: "I_5", Repository: "owner/repo", Number: 5}}
+	skipped, err := undoEdit(nil, entry)
+	if err != nil || skipped != "the previous value was not recorded" {
+		t.Errorf("undoEdit() = %q, %v", skipped, err)
+	}
+}
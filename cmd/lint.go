@@ -0,0 +1,636 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintRepoFlag   string
+	lintFormatFlag string
+	lintCheckFlag  []string
+	lintFixFlag    bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [<issue>]",
+	Short: "Check hierarchies for common problems",
+	Long: `Run health checks over every issue of a hierarchy.
+
+Without an issue, every hierarchy in the repository is checked. Each finding
+has a severity (error, warning or note); the command fails if any error is
+found. Use --fix to apply safe remedies, such as closing open parents whose
+sub-issues are all closed.
+
+Checks:
+  issue-type           Sub-issue type not allowed below its parent (error)
+  closed-parent        Closed issue with open sub-issues (error)
+  completed-parent     Open issue whose sub-issues are all closed, fixable (warning)
+  archived-repository  Sub-issue in an archived repository (warning)
+  empty-parent         Epic, or other type that should have sub-issues, without any (warning)
+  max-depth            Sub-issues nested deeper than lint.maxDepth (warning)
+  missing-assignee     Open leaf sub-issue without assignees (note)
+
+The issue type rules, lint.maxDepth and lint.disable are read from
+.github/sub-issues.yml in the repository, or from the file given with --config:
+
+  issueTypes:
+    Initiative: [Epic]
+    Epic: [Task, Bug]
+    Task: []
+  lint:
+    maxDepth: 3
+    disable: [missing-assignee]
+
+Examples:
+  # Check the hierarchy below issue #123
+  gh sub-issues lint 123
+
+  # Check every hierarchy in a repository
+  gh sub-issues lint --repo owner/repo
+
+  # Only some checks, and close completed parents
+  gh sub-issues lint 123 --check completed-parent --fix
+
+  # SARIF for code scanning tools
+  gh sub-issues lint --format sarif > hierarchy.sarif`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLint,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(lintCmd)
+
+	// Add flags
+	lintCmd.Flags().StringVarP(&lintRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	lintCmd.Flags().StringVar(&lintFormatFlag, "format", "text", "Output format: {text|json|sarif}")
+	lintCmd.Flags().StringArrayVar(&lintCheckFlag, "check", nil, "Only run this check (repeatable)")
+	lintCmd.Flags().BoolVar(&lintFixFlag, "fix", false, "Apply safe automatic fixes")
+}
+
+// lintSeverity is the severity of a finding, named after the SARIF levels
+type lintSeverity string
+
+const (
+	severityError   lintSeverity = "error"
+	severityWarning lintSeverity = "warning"
+	severityNote    lintSeverity = "note"
+)
+
+// lintNode is an issue of a hierarchy together with its position
+type lintNode struct {
+	*HierarchyNode
+	Parent *HierarchyNode
+	Depth  int
+}
+
+// lintCheck is a health check run on every issue of a hierarchy
+type lintCheck struct {
+	ID          string
+	Description string
+	Severity    lintSeverity
+
+	// Run returns the problem with an issue, or "" if there is none
+	Run func(node *lintNode, config *RepoConfig) string
+
+	// Fix repairs the problem, for checks that have a safe remedy
+	Fix func(client *api.GraphQLClient, node *HierarchyNode) error
+}
+
+// lintChecks are all available checks, in the order they are run
+var lintChecks = []lintCheck{
+	{
+		ID:          "issue-type",
+		Description: "Sub-issue type not allowed below its parent",
+		Severity:    severityError,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if node.Parent == nil {
+				return ""
+			}
+			if err := config.checkIssueTypes(node.Parent.IssueType, node.IssueType); err != nil {
+				return err.Error()
+			}
+			return ""
+		},
+	},
+	{
+		ID:          "closed-parent",
+		Description: "Closed issue with open sub-issues",
+		Severity:    severityError,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if node.State != "closed" {
+				return ""
+			}
+			open := 0
+			for _, child := range node.SubIssues {
+				if child.State == "open" {
+					open++
+				}
+			}
+			if open == 0 {
+				return ""
+			}
+			return fmt.Sprintf("Closed, but %d of %d sub-issues are still open", open, len(node.SubIssues))
+		},
+	},
+	{
+		ID:          "completed-parent",
+		Description: "Open issue whose sub-issues are all closed",
+		Severity:    severityWarning,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if node.State != "open" || !isCompleted(node.HierarchyNode) {
+				return ""
+			}
+			return fmt.Sprintf("Open, but all %d sub-issues are done", len(node.SubIssues))
+		},
+		Fix: func(client *api.GraphQLClient, node *HierarchyNode) error {
+			return setIssueState(client, node.ID, "closed")
+		},
+	},
+	{
+		ID:          "archived-repository",
+		Description: "Sub-issue in an archived repository",
+		Severity:    severityWarning,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if node.Parent == nil || !node.Archived {
+				return ""
+			}
+			return fmt.Sprintf("In archived repository %s", node.Repository)
+		},
+	},
+	{
+		ID:          "empty-parent",
+		Description: "Issue of a type that should have sub-issues, without any",
+		Severity:    severityWarning,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if node.State != "open" || len(node.SubIssues) > 0 || node.IssueType == "" {
+				return ""
+			}
+			container := strings.EqualFold(node.IssueType, "Epic")
+			if config.hasTypeRules() {
+				container = config.isContainerType(node.IssueType)
+			}
+			if !container {
+				return ""
+			}
+			return fmt.Sprintf("%s without sub-issues", node.IssueType)
+		},
+	},
+	{
+		ID:          "max-depth",
+		Description: "Sub-issues nested deeper than lint.maxDepth",
+		Severity:    severityWarning,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if config == nil || config.Lint.MaxDepth == 0 || node.Depth != config.Lint.MaxDepth+1 {
+				return ""
+			}
+			return fmt.Sprintf("Nested %d levels deep, more than the %d allowed", node.Depth, config.Lint.MaxDepth)
+		},
+	},
+	{
+		ID:          "missing-assignee",
+		Description: "Open leaf sub-issue without assignees",
+		Severity:    severityNote,
+		Run: func(node *lintNode, config *RepoConfig) string {
+			if node.Parent == nil || node.State != "open" || len(node.SubIssues) > 0 || len(node.Assignees) > 0 {
+				return ""
+			}
+			return "No assignee"
+		},
+	},
+}
+
+// isCompleted reports whether an issue has sub-issues that are all closed, or completed themselves
+func isCompleted(node *HierarchyNode) bool {
+	if len(node.SubIssues) == 0 {
+		return false
+	}
+	for _, child := range node.SubIssues {
+		if child.State != "closed" && !isCompleted(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectLintChecks returns the checks to run: only the given IDs if any, without disabled ones
+func selectLintChecks(only []string, config *RepoConfig) ([]lintCheck, error) {
+	known := make(map[string]bool)
+	for _, check := range lintChecks {
+		known[check.ID] = true
+	}
+
+	var disabled []string
+	if config != nil {
+		disabled = config.Lint.Disable
+	}
+	for _, id := range append(append([]string{}, only...), disabled...) {
+		if !known[id] {
+			var ids []string
+			for _, check := range lintChecks {
+				ids = append(ids, check.ID)
+			}
+			return nil, fmt.Errorf("unknown check %q (available: %s)", id, strings.Join(ids, ", "))
+		}
+	}
+
+	var checks []lintCheck
+	for _, check := range lintChecks {
+		if len(only) > 0 && !containsFold(only, check.ID) {
+			continue
+		}
+		if len(only) == 0 && containsFold(disabled, check.ID) {
+			continue
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// LintFinding is a problem found by a check
+type LintFinding struct {
+	Check    string       `json:"check"`
+	Severity lintSeverity `json:"severity"`
+	Issue    IssueLink    `json:"issue"`
+	Message  string       `json:"message"`
+	Fixable  bool         `json:"fixable,omitempty"`
+	Fixed    bool         `json:"fixed,omitempty"`
+
+	node  *HierarchyNode
+	depth int
+	fix   func(client *api.GraphQLClient, node *HierarchyNode) error
+}
+
+// runLintChecks runs the checks on root and all of its descendants
+func runLintChecks(root *HierarchyNode, config *RepoConfig, checks []lintCheck) []LintFinding {
+	var findings []LintFinding
+
+	visit := func(node *lintNode) {
+		for _, check := range checks {
+			message := check.Run(node, config)
+			if message == "" {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Check:    check.ID,
+				Severity: check.Severity,
+				Issue:    nodeLinks([]*HierarchyNode{node.HierarchyNode})[0],
+				Message:  message,
+				Fixable:  check.Fix != nil,
+				node:     node.HierarchyNode,
+				depth:    node.Depth,
+				fix:      check.Fix,
+			})
+		}
+	}
+
+	visit(&lintNode{HierarchyNode: root})
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		visit(&lintNode{HierarchyNode: node, Parent: parent, Depth: depth})
+	})
+	return findings
+}
+
+// fixLintFindings applies the fixes of fixable findings, deepest issues first
+// so parents are closed after their sub-issues
+func fixLintFindings(cmd *cobra.Command, client *api.GraphQLClient, findings []LintFinding, out io.Writer) int {
+	var fixable []*LintFinding
+	for i := range findings {
+		if findings[i].fix != nil {
+			fixable = append(fixable, &findings[i])
+		}
+	}
+	sort.SliceStable(fixable, func(i, j int) bool { return fixable[i].depth > fixable[j].depth })
+
+	failed := 0
+	for _, finding := range fixable {
+		if err := finding.fix(client, finding.node); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "✗ Failed to fix #%d: %v\n", finding.Issue.Number, err)
+			failed++
+			continue
+		}
+		finding.Fixed = true
+		fmt.Fprintf(out, "✓ Fixed #%d (%s)\n", finding.Issue.Number, finding.Check)
+	}
+	return failed
+}
+
+// countSeverities counts the unfixed findings of each severity
+func countSeverities(findings []LintFinding) map[lintSeverity]int {
+	counts := make(map[lintSeverity]int)
+	for _, finding := range findings {
+		if !finding.Fixed {
+			counts[finding.Severity]++
+		}
+	}
+	return counts
+}
+
+// formatLintText formats findings as one line each, followed by a summary
+func formatLintText(findings []LintFinding, repository string) string {
+	var output strings.Builder
+
+	symbols := map[lintSeverity]string{severityError: "✗", severityWarning: "!", severityNote: "-"}
+	for _, finding := range findings {
+		line := fmt.Sprintf("%s %s %s [%s]", symbols[finding.Severity], linkRef(finding.Issue, repository), finding.Message, finding.Check)
+		if finding.Fixed {
+			line += " (fixed)"
+		}
+		output.WriteString(line + "\n")
+	}
+
+	counts := countSeverities(findings)
+	if counts[severityError]+counts[severityWarning]+counts[severityNote] == 0 {
+		output.WriteString("✓ No problems found\n")
+		return output.String()
+	}
+	output.WriteString(fmt.Sprintf("\n%d errors, %d warnings, %d notes\n",
+		counts[severityError], counts[severityWarning], counts[severityNote]))
+	return output.String()
+}
+
+// formatLintJSON formats findings as JSON
+func formatLintJSON(findings []LintFinding) (string, error) {
+	if findings == nil {
+		findings = []LintFinding{}
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"findings": findings}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// sarifLog is the subset of SARIF 2.1.0 written by lint
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level lintSeverity `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     lintSeverity    `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+// sarifPhysicalLocation points code scanning, which requires a file, at the
+// configuration file that holds the hierarchy rules
+type sarifPhysicalLocation struct {
+	ArtifactLocation struct {
+		URI string `json:"uri"`
+	} `json:"artifactLocation"`
+	Region struct {
+		StartLine int `json:"startLine"`
+	} `json:"region"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+// formatLintSARIF formats unfixed findings as a SARIF 2.1.0 log
+func formatLintSARIF(findings []LintFinding, checks []lintCheck) (string, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gh-sub-issues",
+			InformationURI: "https://github.com/yahsan2/gh-sub-issues",
+		}},
+		Results: []sarifResult{},
+	}
+	for _, check := range checks {
+		rule := sarifRule{ID: check.ID, ShortDescription: sarifMessage{Text: check.Description}}
+		rule.DefaultConfiguration.Level = check.Severity
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+	}
+
+	var physical sarifPhysicalLocation
+	physical.ArtifactLocation.URI = repoConfigPath
+	physical.Region.StartLine = 1
+
+	for _, finding := range findings {
+		if finding.Fixed {
+			continue
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  finding.Check,
+			Level:   finding.Severity,
+			Message: sarifMessage{Text: fmt.Sprintf("%s#%d %s: %s", finding.Issue.Repository, finding.Issue.Number, finding.Issue.Title, finding.Message)},
+			Locations: []sarifLocation{{PhysicalLocation: physical, LogicalLocations: []sarifLogicalLocation{{
+				Name:               fmt.Sprintf("%s#%d", finding.Issue.Repository, finding.Issue.Number),
+				FullyQualifiedName: finding.Issue.URL,
+				Kind:               "object",
+			}}}},
+		})
+	}
+
+	data, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// findHierarchyRoots returns the numbers of issues that have sub-issues but no parent
+func findHierarchyRoots(client *api.GraphQLClient, owner, repo string) ([]int, error) {
+	query := `
+		query($owner: String!, $repo: String!, $after: String) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: 100, after: $after, orderBy: {field: CREATED_AT, direction: ASC}) {
+					nodes {
+						number
+						parent {
+							id
+						}
+						subIssuesSummary {
+							total
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+		"after": nil,
+	}
+
+	var roots []int
+	for {
+		var response struct {
+			Repository struct {
+				Issues struct {
+					Nodes []struct {
+						Number int `json:"number"`
+						Parent *struct {
+							ID string `json:"id"`
+						} `json:"parent"`
+						SubIssuesSummary struct {
+							Total int `json:"total"`
+						} `json:"subIssuesSummary"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+
+		if err := client.Do(query, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to list issues in %s/%s: %w", owner, repo, err)
+		}
+
+		for _, node := range response.Repository.Issues.Nodes {
+			if node.Parent == nil && node.SubIssuesSummary.Total > 0 {
+				roots = append(roots, node.Number)
+			}
+		}
+
+		if !response.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Repository.Issues.PageInfo.EndCursor
+	}
+
+	return roots, nil
+}
+
+// runLint is the main command logic for lint
+func runLint(cmd *cobra.Command, args []string) error {
+	switch lintFormatFlag {
+	case "text", "json", "sarif":
+	default:
+		return fmt.Errorf("invalid format: %s (expected text, json or sarif)", lintFormatFlag)
+	}
+
+	owner, repo, err := resolveRepo(lintRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	var roots []int
+	if len(args) == 1 {
+		ref, err := parseIssueReference(args[0], owner, repo)
+		if err != nil {
+			return fmt.Errorf("invalid issue: %w", err)
+		}
+		owner, repo, roots = ref.Owner, ref.Repo, []int{ref.Number}
+	}
+
+	config, err := loadRepoConfig(owner, repo)
+	if err != nil {
+		return err
+	}
+	checks, err := selectLintChecks(lintCheckFlag, config)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	if len(args) == 0 {
+		fmt.Fprintf(cmd.OutOrStderr(), "Finding hierarchies in %s/%s...\n", owner, repo)
+		roots, err = findHierarchyRoots(client, owner, repo)
+		if err != nil {
+			return err
+		}
+	}
+
+	var findings []LintFinding
+	for _, number := range roots {
+		fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", number, owner, repo)
+		root, err := fetchHierarchy(client, owner, repo, number, 0)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, runLintChecks(root, config, checks)...)
+	}
+
+	// Fix progress goes to stderr when stdout carries JSON or SARIF
+	progress := cmd.OutOrStdout()
+	if lintFormatFlag != "text" {
+		progress = cmd.ErrOrStderr()
+	}
+
+	failedFixes := 0
+	if lintFixFlag {
+		failedFixes = fixLintFindings(cmd, client, findings, progress)
+	}
+
+	var output string
+	switch lintFormatFlag {
+	case "json":
+		output, err = formatLintJSON(findings)
+	case "sarif":
+		output, err = formatLintSARIF(findings, checks)
+	default:
+		output = formatLintText(findings, owner+"/"+repo)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to format findings: %w", err)
+	}
+	fmt.Fprint(cmd.OutOrStdout(), output)
+
+	if failedFixes > 0 {
+		return fmt.Errorf("failed to fix %d findings", failedFixes)
+	}
+	if errors := countSeverities(findings)[severityError]; errors > 0 {
+		return fmt.Errorf("found %d errors", errors)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// lintTestTree builds a hierarchy with one problem for most checks
+func lintTestTree() *HierarchyNode {
+	return &HierarchyNode{Repository: "owner/repo", Number: 1, Title: "Initiative", State: "open", IssueType: "Initiative",
+		SubIssues: []*HierarchyNode{
+			{Repository: "owner/repo", Number: 2, Title: "Done epic", State: "open", IssueType: "Epic", Assignees: []string{"alice"},
+				SubIssues: []*HierarchyNode{
+					{Repository: "owner/repo", Number: 3, Title: "Task", State: "closed", IssueType: "Task"},
+				}},
+			{Repository: "owner/repo", Number: 4, Title: "Closed epic", State: "closed", IssueType: "Epic",
+				SubIssues: []*HierarchyNode{
+					{Repository: "owner/old", Number: 5, Title: "Archived", State: "open", IssueType: "Task",
+						Archived: true, Assignees: []string{"bob"}},
+					{Repository: "owner/repo", Number: 6, Title: "Wrong type", State: "closed", IssueType: "Initiative"},
+				}},
+			{Repository: "owner/repo", Number: 7, Title: "Empty epic", State: "open", IssueType: "Epic"},
+		}}
+}
+
+// findingKeys returns "check#number" for each finding
+func findingKeys(findings []LintFinding) []string {
+	var keys []string
+	for _, finding := range findings {
+		keys = append(keys, finding.Check+"#"+strings.TrimPrefix(linkRef(finding.Issue, "owner/repo"), "#"))
+	}
+	return keys
+}
+
+func TestRunLintChecks(t *testing.T) {
+	config, err := parseRepoConfig([]byte(testRepoConfig + "lint:\n  maxDepth: 1\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checks, err := selectLintChecks(nil, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := strings.Join(findingKeys(runLintChecks(lintTestTree(), config, checks)), " ")
+	want := "completed-parent#2 max-depth#3 closed-parent#4 archived-repository#owner/old#5 max-depth#owner/old#5 " +
+		"issue-type#6 max-depth#6 empty-parent#7 missing-assignee#7"
+	if got != want {
+		t.Errorf("findings:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestSelectLintChecks(t *testing.T) {
+	config, err := parseRepoConfig([]byte("lint:\n  disable: [missing-assignee]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks, err := selectLintChecks(nil, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checks) != len(lintChecks)-1 {
+		t.Errorf("got %d checks, want %d", len(checks), len(lintChecks)-1)
+	}
+
+	checks, err = selectLintChecks([]string{"completed-parent"}, config)
+	if err != nil || len(checks) != 1 || checks[0].ID != "completed-parent" {
+		t.Errorf("--check completed-parent: got %v, %v", checks, err)
+	}
+
+	if _, err := selectLintChecks([]string{"nope"}, nil); err == nil {
+		t.Error("expected error for unknown check")
+	}
+}
+
+func TestIsCompleted(t *testing.T) {
+	nested := &HierarchyNode{State: "open", SubIssues: []*HierarchyNode{
+		{State: "closed"},
+		{State: "open", SubIssues: []*HierarchyNode{{State: "closed"}}},
+	}}
+	if !isCompleted(nested) {
+		t.Error("expected nested hierarchy with only closed leaves to be completed")
+	}
+	if isCompleted(&HierarchyNode{State: "open"}) {
+		t.Error("an issue without sub-issues is not completed")
+	}
+	nested.SubIssues[1].SubIssues[0].State = "open"
+	if isCompleted(nested) {
+		t.Error("expected open leaf to block completion")
+	}
+}
+
+func TestFormatLintText(t *testing.T) {
+	findings := []LintFinding{
+		{Check: "closed-parent", Severity: severityError, Issue: IssueLink{Repository: "owner/repo", Number: 4}, Message: "Closed, but 1 of 2 sub-issues are still open"},
+		{Check: "completed-parent", Severity: severityWarning, Issue: IssueLink{Repository: "owner/repo", Number: 2}, Message: "Open, but all 1 sub-issues are done", Fixed: true},
+		{Check: "missing-assignee", Severity: severityNote, Issue: IssueLink{Repository: "owner/other", Number: 7}, Message: "No assignee"},
+	}
+
+	want := "✗ #4 Closed, but 1 of 2 sub-issues are still open [closed-parent]\n" +
+		"! #2 Open, but all 1 sub-issues are done [completed-parent] (fixed)\n" +
+		"- owner/other#7 No assignee [missing-assignee]\n" +
+		"\n1 errors, 0 warnings, 1 notes\n"
+	if got := formatLintText(findings, "owner/repo"); got != want {
+		t.Errorf("formatLintText() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := formatLintText(nil, "owner/repo"); got != "✓ No problems found\n" {
+		t.Errorf("formatLintText(nil) = %q", got)
+	}
+}
+
+func TestFormatLintSARIF(t *testing.T) {
+	findings := []LintFinding{
+		{Check: "closed-parent", Severity: severityError, Issue: IssueLink{Repository: "owner/repo", Number: 4, Title: "Epic", URL: "https://github.com/owner/repo/issues/4"}, Message: "Closed"},
+		{Check: "completed-parent", Severity: severityWarning, Issue: IssueLink{Repository: "owner/repo", Number: 2}, Fixed: true},
+	}
+
+	output, err := formatLintSARIF(findings, lintChecks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(lintChecks) {
+		t.Errorf("got %d rules, want %d", len(run.Tool.Driver.Rules), len(lintChecks))
+	}
+	if len(run.Results) != 1 {
+		t.Fatalf("got %d results, want 1 (fixed findings are left out)", len(run.Results))
+	}
+	result := run.Results[0]
+	if result.RuleID != "closed-parent" || result.Level != severityError ||
+		result.Locations[0].LogicalLocations[0].Name != "owner/repo#4" ||
+		result.Locations[0].LogicalLocations[0].FullyQualifiedName != "https://github.com/owner/repo/issues/4" ||
+		result.Locations[0].PhysicalLocation.ArtifactLocation.URI != ".github/sub-issues.yml" ||
+		result.Locations[0].PhysicalLocation.Region.StartLine != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
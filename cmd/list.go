@@ -3,31 +3,42 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/cli/go-gh/v2/pkg/text"
 	"github.com/spf13/cobra"
 )
 
 var (
-	listStateFlag  string
-	listLimitFlag  int
-	listJSONFlag   bool
-	listWebFlag    bool
-	listRepoFlag   string
+	listStateFlag   string
+	listLimitFlag   int
+	listJSONFlag    bool
+	listWebFlag     bool
+	listRepoFlag    string
+	listFormatFlag  string
+	listDepthFlag   int
+	listDepsFlag    bool
+	listColumnsFlag string
+	listProjectFlag string
+	listFilterFlag  []string
+	listWatchFlag   time.Duration
+	listNotifyFlag  bool
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list <parent-issue>",
+	Use:   "list [<parent-issue>]",
 	Short: "List all sub-issues for a parent issue",
 	Long: `List all sub-issues connected to a parent issue.
 
 Supports multiple output formats:
-- Colored output for terminal (TTY)
+- Colored table sized to the terminal width (TTY)
 - Plain text for scripts (non-TTY)
 - JSON for programmatic use (--json)
+- Markdown tables and checklists, and CSV for spreadsheets (--format)
+- Mermaid, DOT and PlantUML graphs of the whole hierarchy (--format)
 
 Examples:
   # List sub-issues for issue #123
@@ -43,8 +54,29 @@ Examples:
   gh sub-issues list 123 --json
   
   # Limit results
-  gh sub-issues list 123 --limit 10`,
-	Args: cobra.ExactArgs(1),
+  gh sub-issues list 123 --limit 10
+  
+  # Markdown table for release notes
+  gh sub-issues list 123 --format markdown --state all
+  
+  # CSV with selected columns
+  gh sub-issues list 123 --format csv --columns number,title,assignees
+  
+  # Project status, iteration and estimate as columns, filtered by status
+  gh sub-issues list 123 --project 4 --filter "Status=In Progress"
+  
+  # Mermaid graph including blocked-by dependencies
+  gh sub-issues list 123 --format mermaid --dependencies
+  
+  # Graphviz graph of the first two levels
+  gh sub-issues list 123 --format dot --depth 2 | dot -Tsvg > plan.svg
+  
+  # Refresh every 10 seconds and ring the bell when everything is closed
+  gh sub-issues list 123 --watch=10s --notify
+  
+  # Pick the parent issue interactively
+  gh sub-issues list`,
+	Args: exactArgsOrInteractive(1),
 	RunE: runList,
 }
 
@@ -58,22 +90,45 @@ func init() {
 	listCmd.Flags().BoolVar(&listJSONFlag, "json", false, "Output in JSON format")
 	listCmd.Flags().BoolVarP(&listWebFlag, "web", "w", false, "Open in web browser")
 	listCmd.Flags().StringVarP(&listRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	listCmd.Flags().StringVar(&listFormatFlag, "format", "", "Output format: {markdown|checklist|csv|mermaid|dot|plantuml}")
+	listCmd.Flags().StringVar(&listColumnsFlag, "columns", strings.Join(defaultListColumns, ","), "Comma-separated columns for markdown and csv formats")
+	listCmd.Flags().IntVar(&listDepthFlag, "depth", 0, "Maximum hierarchy depth for graph formats (0 for all levels)")
+	listCmd.Flags().BoolVar(&listDepsFlag, "dependencies", false, "Include blocked-by dependencies as dashed edges in graph formats")
+	listCmd.Flags().StringVar(&listProjectFlag, "project", "", "Show field values from this project: {NUMBER|OWNER/NUMBER}")
+	listCmd.Flags().StringArrayVar(&listFilterFlag, "filter", nil, "Only show sub-issues whose project field NAME has VALUE, in NAME=VALUE format (repeatable)")
+	listCmd.Flags().DurationVar(&listWatchFlag, "watch", 0, "Refresh the list every interval until interrupted; set the interval with --watch=DURATION, at least 5s (default 30s)")
+	listCmd.Flags().Lookup("watch").NoOptDefVal = "30s"
+	listCmd.Flags().BoolVar(&listNotifyFlag, "notify", false, "With --watch, ring the terminal bell when all sub-issues are closed")
+	listCmd.MarkFlagsMutuallyExclusive("json", "format")
+	listCmd.MarkFlagsMutuallyExclusive("watch", "web")
 }
 
 // SubIssue represents a sub-issue
 type SubIssue struct {
+	ID        string   `json:"-"`
 	Number    int      `json:"number"`
 	Title     string   `json:"title"`
 	State     string   `json:"state"`
 	URL       string   `json:"url"`
 	Assignees []string `json:"assignees,omitempty"`
+	IssueType string   `json:"issueType,omitempty"`
+	BlockedBy []IssueLink `json:"blockedBy,omitempty"`
+	Blocking  []IssueLink `json:"blocking,omitempty"`
+	
+	// ProjectFields are the field values of the item in the project given by --project
+	ProjectFields map[string]string `json:"projectFields,omitempty"`
+	
+	// SubIssueCount is the number of sub-issues of this sub-issue
+	SubIssueCount int `json:"-"`
 }
 
 // ParentIssue represents the parent issue
 type ParentIssue struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	State  string `json:"state"`
+	ID        string `json:"-"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	IssueType string `json:"issueType,omitempty"`
 }
 
 // ListResult represents the result of listing sub-issues
@@ -82,10 +137,16 @@ type ListResult struct {
 	SubIssues []SubIssue  `json:"subIssues"`
 	Total     int         `json:"total"`
 	OpenCount int         `json:"openCount"`
+	
+	// ProjectFields are the names of the project fields shown as columns
+	ProjectFields []string `json:"projectFields,omitempty"`
+	
+	// Changed holds the IDs of sub-issues that changed since the last refresh of --watch
+	Changed map[string]bool `json:"-"`
 }
 
 // getSubIssues fetches sub-issues for a parent issue
-func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, limit int) (*ListResult, error) {
+func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, limit int, state string) (*ListResult, error) {
 	// First, get the parent issue details
 	parentQuery := `
 		query($owner: String!, $repo: String!, $number: Int!) {
@@ -95,6 +156,9 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 					number
 					title
 					state
+					issueType {
+						name
+					}
 				}
 			}
 		}`
@@ -102,10 +166,13 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 	var parentResponse struct {
 		Repository struct {
 			Issue struct {
-				ID     string `json:"id"`
-				Number int    `json:"number"`
-				Title  string `json:"title"`
-				State  string `json:"state"`
+				ID        string `json:"id"`
+				Number    int    `json:"number"`
+				Title     string `json:"title"`
+				State     string `json:"state"`
+				IssueType *struct {
+					Name string `json:"name"`
+				} `json:"issueType"`
 			} `json:"issue"`
 		} `json:"repository"`
 	}
@@ -132,6 +199,7 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 				issue(number: $number) {
 					subIssues(first: $limit) {
 						nodes {
+							id
 							number
 							title
 							state
@@ -141,6 +209,20 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 									login
 								}
 							}
+							issueType {
+								name
+							}
+							subIssuesSummary {
+								total
+							}
+							blockedBy(first: 20) {
+								nodes {` + linkedIssueFields + `
+								}
+							}
+							blocking(first: 20) {
+								nodes {` + linkedIssueFields + `
+								}
+							}
 						}
 					}
 				}
@@ -152,6 +234,7 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 			Issue struct {
 				SubIssues struct {
 					Nodes []struct {
+						ID        string `json:"id"`
 						Number    int    `json:"number"`
 						Title     string `json:"title"`
 						State     string `json:"state"`
@@ -161,6 +244,18 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 								Login string `json:"login"`
 							} `json:"nodes"`
 						} `json:"assignees"`
+						IssueType *struct {
+							Name string `json:"name"`
+						} `json:"issueType"`
+						SubIssuesSummary struct {
+							Total int `json:"total"`
+						} `json:"subIssuesSummary"`
+						BlockedBy struct {
+							Nodes []linkedIssue `json:"nodes"`
+						} `json:"blockedBy"`
+						Blocking struct {
+							Nodes []linkedIssue `json:"nodes"`
+						} `json:"blocking"`
 					} `json:"nodes"`
 				} `json:"subIssues"`
 			} `json:"issue"`
@@ -182,6 +277,7 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 	// Build result
 	result := &ListResult{
 		Parent: ParentIssue{
+			ID:     parentResponse.Repository.Issue.ID,
 			Number: parentResponse.Repository.Issue.Number,
 			Title:  parentResponse.Repository.Issue.Title,
 			State:  strings.ToLower(parentResponse.Repository.Issue.State),
@@ -190,6 +286,9 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 		Total:     0,
 		OpenCount: 0,
 	}
+	if parentResponse.Repository.Issue.IssueType != nil {
+		result.Parent.IssueType = parentResponse.Repository.Issue.IssueType.Name
+	}
 	
 	// Process sub-issues
 	for _, node := range subIssuesResponse.Repository.Issue.SubIssues.Nodes {
@@ -203,16 +302,27 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 		}
 		
 		subIssue := SubIssue{
-			Number:    node.Number,
-			Title:     node.Title,
-			State:     strings.ToLower(node.State),
-			URL:       node.URL,
-			Assignees: assignees,
+			ID:            node.ID,
+			Number:        node.Number,
+			Title:         node.Title,
+			State:         strings.ToLower(node.State),
+			URL:           node.URL,
+			Assignees:     assignees,
+			SubIssueCount: node.SubIssuesSummary.Total,
+		}
+		if node.IssueType != nil {
+			subIssue.IssueType = node.IssueType.Name
+		}
+		for _, blocker := range node.BlockedBy.Nodes {
+			subIssue.BlockedBy = append(subIssue.BlockedBy, blocker.toLink())
+		}
+		for _, blocked := range node.Blocking.Nodes {
+			subIssue.Blocking = append(subIssue.Blocking, blocked.toLink())
 		}
 		
 		// Apply state filter
-		if listStateFlag != "all" {
-			if listStateFlag != subIssue.State {
+		if state != "all" {
+			if state != subIssue.State {
 				continue
 			}
 		}
@@ -228,12 +338,89 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 	return result, nil
 }
 
-// formatTTY formats output for terminal with colors
-func formatTTY(result *ListResult) string {
+// ttyColumn is a column of the terminal table
+type ttyColumn struct {
+	header string
+	values []string
+	width  int
+	limit  int
+	always bool
+	style  func(i int, s string) string
+}
+
+// measure sets the column width from its cells and reports whether the column is shown
+func (c *ttyColumn) measure() bool {
+	content := 0
+	for _, value := range c.values {
+		content = max(content, text.DisplayWidth(value))
+	}
+	if content == 0 && c.limit > 0 && !c.always {
+		return false
+	}
+	
+	c.width = max(c.width, content, text.DisplayWidth(c.header))
+	if c.limit > 0 {
+		c.width = min(c.width, c.limit)
+	}
+	return true
+}
+
+// cell truncates and pads a value to the column width
+func (c *ttyColumn) cell(value string, last bool) string {
+	value = text.Truncate(c.width, value)
+	if last {
+		return value
+	}
+	return text.PadRight(c.width, value)
+}
+
+// renderTable lays out the rows of the columns with a header, dropping empty
+// columns. The flex column takes the width left by the others.
+func renderTable(columns []*ttyColumn, flex *ttyColumn, width int, cs *colorScheme) string {
+	// Measure columns by display width so wide characters line up
+	const gap = "  "
+	var visible []*ttyColumn
+	fixed := 0
+	for _, column := range columns {
+		if !column.measure() {
+			continue
+		}
+		visible = append(visible, column)
+		if column != flex {
+			fixed += column.width + len(gap)
+		}
+	}
+	flex.width = max(min(flex.width, width-fixed), 10)
+
+	var output strings.Builder
+	cells := make([]string, len(visible))
+	for c, column := range visible {
+		cells[c] = cs.Gray(column.cell(column.header, c == len(visible)-1))
+	}
+	output.WriteString(strings.TrimRight(strings.Join(cells, gap), " ") + "\n")
+
+	for i := range columns[0].values {
+		for c, column := range visible {
+			cells[c] = column.cell(column.values[i], c == len(visible)-1)
+			if column.style != nil {
+				cells[c] = column.style(i, cells[c])
+			}
+		}
+		output.WriteString(strings.TrimRight(strings.Join(cells, gap), " ") + "\n")
+	}
+	return output.String()
+}
+
+// formatTTY formats output for terminal as a table that fits the given width
+func formatTTY(result *ListResult, width int, cs *colorScheme) string {
 	var output strings.Builder
 	
 	// Header
-	output.WriteString(fmt.Sprintf("\nParent: #%d - %s\n\n", result.Parent.Number, result.Parent.Title))
+	parentType := ""
+	if result.Parent.IssueType != "" {
+		parentType = " " + cs.Gray("("+result.Parent.IssueType+")")
+	}
+	output.WriteString(fmt.Sprintf("\n%s #%d - %s%s\n\n", cs.Bold("Parent:"), result.Parent.Number, result.Parent.Title, parentType))
 	
 	if result.Total == 0 {
 		output.WriteString("No sub-issues found.\n")
@@ -242,30 +429,75 @@ func formatTTY(result *ListResult) string {
 	
 	// Summary
 	closedCount := result.Total - result.OpenCount
-	output.WriteString(fmt.Sprintf("SUB-ISSUES (%d total, %d open, %d closed)\n", 
-		result.Total, result.OpenCount, closedCount))
-	output.WriteString("─────────────────────────────\n")
-	
-	// Sub-issues
+	blockedCount := 0
 	for _, issue := range result.SubIssues {
-		// State icon
-		icon := "🔵" // open
-		if issue.State == "closed" {
-			icon = "✅"
+		if issue.State == "open" && len(openBlockers(issue)) > 0 {
+			blockedCount++
+		}
+	}
+	summary := fmt.Sprintf("%d total, %s, %s", result.Total,
+		cs.Green(fmt.Sprintf("%d open", result.OpenCount)),
+		cs.Magenta(fmt.Sprintf("%d closed", closedCount)))
+	if blockedCount > 0 {
+		summary += ", " + cs.Yellow(fmt.Sprintf("%d blocked", blockedCount))
+	}
+	output.WriteString(fmt.Sprintf("%s (%s)\n\n", cs.Bold("SUB-ISSUES"), summary))
+	
+	// Collect the cells of each column
+	n := len(result.SubIssues)
+	numbers := ttyColumn{header: "ISSUE", values: make([]string, n)}
+	titles := ttyColumn{header: "TITLE", values: make([]string, n)}
+	states := ttyColumn{header: "STATE", values: make([]string, n), width: text.DisplayWidth("closed")}
+	types := ttyColumn{header: "TYPE", values: make([]string, n), limit: max(width/8, 8)}
+	blockers := ttyColumn{header: "BLOCKED BY", values: make([]string, n), limit: max(width/5, 12)}
+	assignees := ttyColumn{header: "ASSIGNEES", values: make([]string, n), limit: max(width/4, 12)}
+	fields := make([]ttyColumn, len(result.ProjectFields))
+	for j, name := range result.ProjectFields {
+		fields[j] = ttyColumn{header: strings.ToUpper(name), values: make([]string, n), limit: max(width/6, 10), always: true}
+	}
+	
+	for i, issue := range result.SubIssues {
+		numbers.values[i] = fmt.Sprintf("#%d", issue.Number)
+		titles.values[i] = issue.Title
+		states.values[i] = issue.State
+		types.values[i] = issue.IssueType
+		if issue.State == "open" {
+			blockers.values[i] = blockerRefs(issue)
 		}
-		
-		// Format line
-		line := fmt.Sprintf("%s #%-4d %-40s [%s]", 
-			icon, issue.Number, truncate(issue.Title, 40), issue.State)
-		
-		// Add assignees if any
 		if len(issue.Assignees) > 0 {
-			line += fmt.Sprintf("   @%s", strings.Join(issue.Assignees, ", @"))
+			assignees.values[i] = "@" + strings.Join(issue.Assignees, ", @")
+		}
+		for j, name := range result.ProjectFields {
+			fields[j].values[i] = issue.ProjectFields[name]
 		}
-		
-		output.WriteString(line + "\n")
 	}
 	
+	styleState := func(i int, s string) string {
+		return cs.ForState(result.SubIssues[i].State, s)
+	}
+	numbers.style = func(i int, s string) string {
+		number := strings.TrimRight(s, " ")
+		return cs.Hyperlink(result.SubIssues[i].URL, styleState(i, number)) + s[len(number):]
+	}
+	states.style = styleState
+	titles.style = func(i int, s string) string {
+		if result.Changed[result.SubIssues[i].ID] {
+			return cs.Highlight(s)
+		}
+		return s
+	}
+	blockers.style = func(i int, s string) string { return cs.Yellow(s) }
+	assignees.style = func(i int, s string) string { return cs.Gray(s) }
+	
+	columns := []*ttyColumn{&numbers, &titles, &states, &types}
+	for j := range fields {
+		columns = append(columns, &fields[j])
+	}
+	columns = append(columns, &blockers, &assignees)
+	
+	// The title takes the remaining space
+	output.WriteString(renderTable(columns, &titles, width, cs))
+	
 	return output.String()
 }
 
@@ -275,8 +507,12 @@ func formatPlain(result *ListResult) string {
 	
 	for _, issue := range result.SubIssues {
 		assignees := strings.Join(issue.Assignees, ",")
-		output.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\n", 
-			issue.Number, issue.State, issue.Title, assignees))
+		output.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s", 
+			issue.Number, issue.State, singleLine(issue.Title), assignees))
+		for _, name := range result.ProjectFields {
+			output.WriteString("\t" + singleLine(issue.ProjectFields[name]))
+		}
+		output.WriteString("\n")
 	}
 	
 	return output.String()
@@ -291,21 +527,37 @@ func formatJSON(result *ListResult) (string, error) {
 	return string(jsonBytes), nil
 }
 
-// truncate truncates a string to max length
-func truncate(s string, max int) string {
-	runes := []rune(s)
-	if len(runes) <= max {
-		return s
-	}
-	return string(runes[:max-3]) + "..."
-}
-
 // runList is the main command logic
 func runList(cmd *cobra.Command, args []string) error {
 	// Get default repository
 	var defaultOwner, defaultRepo string
 	var err error
 	
+	switch listFormatFlag {
+	case "", "markdown", "checklist", "csv", "mermaid", "dot", "plantuml":
+	default:
+		return fmt.Errorf("invalid format: %s (expected markdown, checklist, csv, mermaid, dot or plantuml)", listFormatFlag)
+	}
+	
+	columns, err := parseListColumns(listColumnsFlag)
+	if err != nil {
+		return err
+	}
+	
+	filters, err := parseFieldAssignments(listFilterFlag)
+	if err != nil {
+		return err
+	}
+	if len(filters) > 0 && listProjectFlag == "" {
+		return fmt.Errorf("--filter requires --project")
+	}
+	if cmd.Flags().Changed("watch") && listWatchFlag < minWatchInterval {
+		return fmt.Errorf("invalid watch interval %s: must be at least %s", listWatchFlag, minWatchInterval)
+	}
+	if listNotifyFlag && listWatchFlag == 0 {
+		return fmt.Errorf("--notify requires --watch")
+	}
+	
 	if listRepoFlag != "" {
 		// Parse --repo flag
 		parts := strings.Split(listRepoFlag, "/")
@@ -322,10 +574,18 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 	}
 	
-	// Parse parent issue reference
-	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
-	if err != nil {
-		return fmt.Errorf("invalid parent issue: %w", err)
+	// Parse parent issue reference, or pick it in an interactive terminal
+	var parentRef *IssueReference
+	if len(args) == 0 {
+		parentRef, err = pickListParent(defaultOwner, defaultRepo)
+		if err != nil {
+			return err
+		}
+	} else {
+		parentRef, err = parseIssueReference(args[0], defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
 	}
 	
 	// Handle --web flag
@@ -342,12 +602,70 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create GitHub client: %w", err)
 	}
 	
-	// Get sub-issues
-	result, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, listLimitFlag)
+	if listWatchFlag > 0 {
+		return watchList(cmd, client, parentRef, columns, filters)
+	}
+	
+	output, _, err := renderList(client, parentRef, columns, filters, nil)
 	if err != nil {
 		return err
 	}
 	
+	// Print output
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	
+	return nil
+}
+
+// renderList fetches the sub-issues of a parent and formats them for output.
+// Rows that differ from the previous result are highlighted in the terminal.
+func renderList(client *api.GraphQLClient, parentRef *IssueReference, columns []string, filters []FieldAssignment, previous *ListResult) (string, *ListResult, error) {
+	columns = append([]string{}, columns...)
+	
+	// Graph formats render the whole hierarchy regardless of state
+	if graphFormats[listFormatFlag] {
+		root, err := fetchHierarchy(client, parentRef.Owner, parentRef.Repo, parentRef.Number, listDepthFlag)
+		if err != nil {
+			return "", nil, err
+		}
+		output, err := formatGraph(root, listFormatFlag, listDepsFlag)
+		return output, nil, err
+	}
+	
+	// Get sub-issues. With --filter, all of them are fetched and the limit is
+	// applied to the matches.
+	limit := listLimitFlag
+	if len(filters) > 0 {
+		limit = maxSubIssues
+	}
+	result, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, limit, listStateFlag)
+	if err != nil {
+		return "", nil, err
+	}
+	
+	// Add project field values as extra columns
+	if listProjectFlag != "" {
+		project, err := parseProjectRef(listProjectFlag, parentRef.Owner)
+		if err != nil {
+			return "", nil, err
+		}
+		
+		ids := make([]string, len(result.SubIssues))
+		for i, issue := range result.SubIssues {
+			ids[i] = issue.ID
+		}
+		values, names, err := getProjectFieldValues(client, ids, project)
+		if err != nil {
+			return "", nil, err
+		}
+		applyProjectFields(result, values, names, filters, listLimitFlag)
+		for _, name := range names {
+			columns = append(columns, projectColumnPrefix+name)
+		}
+	}
+	
+	result.Changed = changedSubIssues(previous, result)
+	
 	// Format output
 	var output string
 	
@@ -355,20 +673,30 @@ func runList(cmd *cobra.Command, args []string) error {
 		// JSON output
 		output, err = formatJSON(result)
 		if err != nil {
-			return fmt.Errorf("failed to format JSON: %w", err)
+			return "", nil, fmt.Errorf("failed to format JSON: %w", err)
 		}
-	} else if term.IsTerminal(os.Stdout) {
-		// TTY output with colors
-		output = formatTTY(result)
+	} else if listFormatFlag == "markdown" {
+		output = formatMarkdownTable(result, columns, parentRef.Owner, parentRef.Repo)
+	} else if listFormatFlag == "checklist" {
+		output = formatChecklist(result, parentRef.Owner, parentRef.Repo)
+	} else if listFormatFlag == "csv" {
+		output, err = formatCSV(result, columns)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to format CSV: %w", err)
+		}
+	} else if t := term.FromEnv(); t.IsTerminalOutput() {
+		// TTY output with colors, sized to the terminal
+		width, _, err := t.Size()
+		if err != nil || width <= 0 {
+			width = 80
+		}
+		output = formatTTY(result, width, newColorScheme(t))
 	} else {
 		// Plain text output
 		output = formatPlain(result)
 	}
 	
-	// Print output
-	fmt.Fprint(cmd.OutOrStdout(), output)
-	
-	return nil
+	return output, result, nil
 }
 
 // openInBrowser opens a URL in the default browser
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// listColumns are the columns available to the markdown and csv formats
+var listColumns = []string{"number", "title", "state", "type", "assignees", "url", "blocked_by"}
+
+// defaultListColumns are the columns used when --columns is not given
+var defaultListColumns = []string{"number", "title", "state", "assignees", "url"}
+
+// parseListColumns parses a comma-separated column list
+func parseListColumns(value string) ([]string, error) {
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+
+		valid := false
+		for _, known := range listColumns {
+			if column == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", column, strings.Join(listColumns, ", "))
+		}
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+	return columns, nil
+}
+
+// projectColumnPrefix marks columns that hold project field values
+const projectColumnPrefix = "field:"
+
+// listColumnHeader returns the header text of a column
+func listColumnHeader(column string) string {
+	if name, ok := strings.CutPrefix(column, projectColumnPrefix); ok {
+		return name
+	}
+	return column
+}
+
+// listColumnValue returns the raw value of a column for a sub-issue
+func listColumnValue(issue SubIssue, column string) string {
+	if name, ok := strings.CutPrefix(column, projectColumnPrefix); ok {
+		return issue.ProjectFields[name]
+	}
+	switch column {
+	case "number":
+		return strconv.Itoa(issue.Number)
+	case "title":
+		return issue.Title
+	case "state":
+		return issue.State
+	case "type":
+		return issue.IssueType
+	case "assignees":
+		return strings.Join(issue.Assignees, ",")
+	case "url":
+		return issue.URL
+	case "blocked_by":
+		return blockerRefs(issue)
+	}
+	return ""
+}
+
+// singleLine replaces tabs and line breaks so a value stays on one line
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)), " ")
+}
+
+// formatMarkdownTable formats sub-issues as a GitHub-flavoured Markdown table
+func formatMarkdownTable(result *ListResult, columns []string, owner, repo string) string {
+	var output strings.Builder
+
+	header := make([]string, len(columns))
+	separator := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = listColumnHeader(column)
+		if !strings.HasPrefix(column, projectColumnPrefix) {
+			header[i] = strings.ToUpper(column[:1]) + strings.ReplaceAll(column[1:], "_", " ")
+		}
+		separator[i] = "---"
+	}
+	output.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	output.WriteString("| " + strings.Join(separator, " | ") + " |\n")
+
+	escape := strings.NewReplacer("|", `\|`)
+	for _, issue := range result.SubIssues {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "number":
+				cells[i] = issueRefString(issue.URL, issue.Number, owner, repo)
+			case "assignees":
+				if len(issue.Assignees) > 0 {
+					cells[i] = "@" + strings.Join(issue.Assignees, ", @")
+				}
+			default:
+				cells[i] = escape.Replace(singleLine(listColumnValue(issue, column)))
+			}
+		}
+		output.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return output.String()
+}
+
+// formatChecklist formats sub-issues as a Markdown task list
+func formatChecklist(result *ListResult, owner, repo string) string {
+	var output strings.Builder
+
+	for _, issue := range result.SubIssues {
+		check := " "
+		if issue.State == "closed" {
+			check = "x"
+		}
+		output.WriteString(fmt.Sprintf("- [%s] %s %s\n",
+			check, issueRefString(issue.URL, issue.Number, owner, repo), singleLine(issue.Title)))
+	}
+
+	return output.String()
+}
+
+// formatCSV formats sub-issues as RFC 4180 CSV with a header row
+func formatCSV(result *ListResult, columns []string) (string, error) {
+	var output strings.Builder
+	writer := csv.NewWriter(&output)
+	writer.UseCRLF = true
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = listColumnHeader(column)
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	for _, issue := range result.SubIssues {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = listColumnValue(issue, column)
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func listFormatTestResult() *ListResult {
+	return &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Parent Issue", State: "open"},
+		SubIssues: []SubIssue{
+			{
+				Number:    2,
+				Title:     "Pipe | and\ttab",
+				State:     "open",
+				URL:       "https://github.com/owner/repo/issues/2",
+				Assignees: []string{"user1", "user2"},
+			},
+			{
+				Number: 7,
+				Title:  `Say "hi", then leave`,
+				State:  "closed",
+				URL:    "https://github.com/other/project/issues/7",
+			},
+		},
+		Total:     2,
+		OpenCount: 1,
+	}
+}
+
+func TestParseListColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "all columns",
+			input: "number,title,state,assignees,url",
+			want:  []string{"number", "title", "state", "assignees", "url"},
+		},
+		{
+			name:  "spaces and case",
+			input: " Number , TITLE ",
+			want:  []string{"number", "title"},
+		},
+		{
+			name:    "unknown column",
+			input:   "number,labels",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			input:   " , ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseListColumns(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseListColumns(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseListColumns(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseListColumns(%q) = %v, want %v", tt.input, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatMarkdownTable(t *testing.T) {
+	expected := "| Number | Title | Assignees |\n" +
+		"| --- | --- | --- |\n" +
+		"| #2 | Pipe \\| and tab | @user1, @user2 |\n" +
+		"| other/project#7 | Say \"hi\", then leave |  |\n"
+
+	output := formatMarkdownTable(listFormatTestResult(), []string{"number", "title", "assignees"}, "owner", "repo")
+	if output != expected {
+		t.Errorf("formatMarkdownTable() output mismatch\nGot:\n%s\nExpected:\n%s", output, expected)
+	}
+}
+
+func TestFormatChecklist(t *testing.T) {
+	expected := "- [ ] #2 Pipe | and tab\n" +
+		"- [x] other/project#7 Say \"hi\", then leave\n"
+
+	output := formatChecklist(listFormatTestResult(), "owner", "repo")
+	if output != expected {
+		t.Errorf("formatChecklist() output mismatch\nGot:\n%s\nExpected:\n%s", output, expected)
+	}
+}
+
+func TestFormatCSV(t *testing.T) {
+	expected := "number,title,state,assignees\r\n" +
+		"2,Pipe | and\ttab,open,\"user1,user2\"\r\n" +
+		"7,\"Say \"\"hi\"\", then leave\",closed,\r\n"
+
+	output, err := formatCSV(listFormatTestResult(), []string{"number", "title", "state", "assignees"})
+	if err != nil {
+		t.Fatalf("formatCSV() error: %v", err)
+	}
+	if output != expected {
+		t.Errorf("formatCSV() output mismatch\nGot:\n%q\nExpected:\n%q", output, expected)
+	}
+}
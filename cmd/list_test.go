@@ -2,51 +2,11 @@ package cmd
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
-)
-
-func TestTruncate(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		maxLen   int
-		expected string
-	}{
-		{
-			name:     "short string",
-			input:    "Hello",
-			maxLen:   10,
-			expected: "Hello",
-		},
-		{
-			name:     "exact length",
-			input:    "Hello World",
-			maxLen:   11,
-			expected: "Hello World",
-		},
-		{
-			name:     "long string",
-			input:    "This is a very long string that needs truncation",
-			maxLen:   20,
-			expected: "This is a very lo...",
-		},
-		{
-			name:     "unicode string",
-			input:    "Hello 世界 World",
-			maxLen:   10,
-			expected: "Hello 世...",
-		},
-	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := truncate(tt.input, tt.maxLen)
-			if result != tt.expected {
-				t.Errorf("truncate(%q, %d) = %q, want %q", tt.input, tt.maxLen, result, tt.expected)
-			}
-		})
-	}
-}
+	"github.com/cli/go-gh/v2/pkg/text"
+)
 
 func TestFormatPlain(t *testing.T) {
 	result := &ListResult{
@@ -155,8 +115,9 @@ func TestFormatTTY(t *testing.T) {
 			contains: []string{
 				"Parent: #1 - Parent Issue",
 				"SUB-ISSUES (2 total, 1 open, 1 closed)",
-				"🔵 #2",
-				"✅ #3",
+				"ISSUE  TITLE",
+				"#2     Open sub-issue",
+				"#3     Closed sub-issue",
 			},
 		},
 		{
@@ -180,7 +141,7 @@ func TestFormatTTY(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := formatTTY(tt.result)
+			output := formatTTY(tt.result, 80, &colorScheme{})
 			for _, expected := range tt.contains {
 				if !containsString(output, expected) {
 					t.Errorf("formatTTY() output missing expected string: %q\nFull output:\n%s", expected, output)
@@ -188,4 +149,54 @@ func TestFormatTTY(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestFormatTTYWidth(t *testing.T) {
+	result := &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Parent", State: "open"},
+		SubIssues: []SubIssue{
+			{Number: 2, Title: "日本語のタイトルです", State: "open", Assignees: []string{"user1"}},
+			{Number: 345, Title: "A rather long English title that will not fit", State: "closed"},
+		},
+		Total:     2,
+		OpenCount: 1,
+	}
+
+	output := formatTTY(result, 50, &colorScheme{})
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	table := lines[len(lines)-3:]
+
+	expected := []string{
+		"ISSUE  TITLE                     STATE   ASSIGNEES",
+		"#2     日本語のタイトルです      open    @user1",
+		"#345   A rather long English...  closed",
+	}
+	for i, want := range expected {
+		if table[i] != want {
+			t.Errorf("line %d = %q, want %q", i, table[i], want)
+		}
+	}
+	for _, line := range table {
+		if w := text.DisplayWidth(line); w > 50 {
+			t.Errorf("line %q is %d columns wide, exceeds 50", line, w)
+		}
+	}
+}
+
+func TestFormatTTYColor(t *testing.T) {
+	result := &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Parent", State: "open"},
+		SubIssues: []SubIssue{
+			{Number: 2, Title: "Task", State: "closed", URL: "https://github.com/owner/repo/issues/2"},
+		},
+		Total: 1,
+	}
+
+	output := formatTTY(result, 80, &colorScheme{enabled: true, hyperlinks: true})
+	if !strings.Contains(output, "\x1b]8;;https://github.com/owner/repo/issues/2\x1b\\") {
+		t.Errorf("formatTTY() missing OSC 8 hyperlink\nFull output:\n%q", output)
+	}
+	if !strings.Contains(output, "\x1b[35mclosed") {
+		t.Errorf("formatTTY() missing closed state color\nFull output:\n%q", output)
+	}
+}
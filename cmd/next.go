@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nextRepoFlag       string
+	nextUnassignedFlag bool
+	nextJSONFlag       bool
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next <issue>",
+	Short: "Show what can be started now and the critical path of an epic",
+	Long: `Analyze blocked-by dependencies between all descendants of an issue.
+
+Lists open leaf issues that have no open blockers and can be started now, and
+the longest chain of open issues that block each other (the critical path).
+Dependency cycles are reported, since they can never be resolved.
+
+Examples:
+  # What can the team pick up next?
+  gh sub-issues next 123
+
+  # Only work nobody has claimed yet
+  gh sub-issues next 123 --unassigned
+
+  # JSON output
+  gh sub-issues next 123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNext,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(nextCmd)
+
+	// Add flags
+	nextCmd.Flags().StringVarP(&nextRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	nextCmd.Flags().BoolVar(&nextUnassignedFlag, "unassigned", false, "Only list ready issues without assignees")
+	nextCmd.Flags().BoolVar(&nextJSONFlag, "json", false, "Output in JSON format")
+}
+
+// DependencyAnalysis is the result of analyzing the dependencies of a hierarchy
+type DependencyAnalysis struct {
+	Ready        []IssueLink   `json:"ready"`
+	CriticalPath []IssueLink   `json:"criticalPath"`
+	Cycles       [][]IssueLink `json:"cycles,omitempty"`
+}
+
+// dependencyGraph holds blocked-by edges between the descendants of an issue
+type dependencyGraph struct {
+	nodes  []*HierarchyNode
+	byID   map[string]*HierarchyNode
+	blocks map[string][]string
+}
+
+// buildDependencyGraph collects the descendants of root and the edges from blocker to blocked issue
+func buildDependencyGraph(root *HierarchyNode) *dependencyGraph {
+	g := &dependencyGraph{
+		byID:   make(map[string]*HierarchyNode),
+		blocks: make(map[string][]string),
+	}
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		if _, ok := g.byID[node.ID]; ok {
+			return
+		}
+		g.byID[node.ID] = node
+		g.nodes = append(g.nodes, node)
+	})
+
+	for _, node := range g.nodes {
+		for _, blocker := range node.BlockedBy {
+			if _, ok := g.byID[blocker.ID]; ok {
+				g.blocks[blocker.ID] = append(g.blocks[blocker.ID], node.ID)
+			}
+		}
+	}
+	return g
+}
+
+// readyIssues returns open leaf issues without open blockers, in hierarchy order
+func (g *dependencyGraph) readyIssues(unassigned bool) []*HierarchyNode {
+	var ready []*HierarchyNode
+	for _, node := range g.nodes {
+		if node.State != "open" || len(node.SubIssues) > 0 {
+			continue
+		}
+		if unassigned && len(node.Assignees) > 0 {
+			continue
+		}
+
+		blocked := false
+		for _, blocker := range node.BlockedBy {
+			if blocker.State == "open" {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			ready = append(ready, node)
+		}
+	}
+	return ready
+}
+
+// openSuccessors returns the open issues blocked by an issue
+func (g *dependencyGraph) openSuccessors(id string) []string {
+	var next []string
+	for _, to := range g.blocks[id] {
+		if g.byID[to].State == "open" {
+			next = append(next, to)
+		}
+	}
+	return next
+}
+
+// cycles returns the dependency cycles between open issues
+func (g *dependencyGraph) cycles() [][]*HierarchyNode {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]*HierarchyNode
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+
+		for _, next := range g.openSuccessors(id) {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				start := len(stack) - 1
+				for stack[start] != next {
+					start--
+				}
+				var cycle []*HierarchyNode
+				for _, member := range stack[start:] {
+					cycle = append(cycle, g.byID[member])
+				}
+				cycles = append(cycles, cycle)
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	for _, node := range g.nodes {
+		if node.State == "open" && state[node.ID] == unvisited {
+			visit(node.ID)
+		}
+	}
+	return cycles
+}
+
+// criticalPath returns the longest chain of open issues that block each other.
+// Issues that are part of a cycle are left out, as no chain through them can finish.
+func (g *dependencyGraph) criticalPath(cycles [][]*HierarchyNode) []*HierarchyNode {
+	inCycle := make(map[string]bool)
+	for _, cycle := range cycles {
+		for _, node := range cycle {
+			inCycle[node.ID] = true
+		}
+	}
+
+	length := make(map[string]int)
+	next := make(map[string]string)
+
+	var measure func(id string) int
+	measure = func(id string) int {
+		if l, ok := length[id]; ok {
+			return l
+		}
+		best := 1
+		for _, to := range g.openSuccessors(id) {
+			if inCycle[to] {
+				continue
+			}
+			if l := measure(to) + 1; l > best {
+				best = l
+				next[id] = to
+			}
+		}
+		length[id] = best
+		return best
+	}
+
+	start := ""
+	for _, node := range g.nodes {
+		if node.State != "open" || inCycle[node.ID] {
+			continue
+		}
+		if start == "" || measure(node.ID) > measure(start) {
+			start = node.ID
+		}
+	}
+	if start == "" || length[start] < 2 {
+		return nil
+	}
+
+	var path []*HierarchyNode
+	for id := start; id != ""; id = next[id] {
+		path = append(path, g.byID[id])
+	}
+	return path
+}
+
+// analyzeDependencies finds ready issues, the critical path and cycles below root
+func analyzeDependencies(root *HierarchyNode, unassigned bool) *DependencyAnalysis {
+	g := buildDependencyGraph(root)
+	cycles := g.cycles()
+
+	analysis := &DependencyAnalysis{
+		Ready:        nodeLinks(g.readyIssues(unassigned)),
+		CriticalPath: nodeLinks(g.criticalPath(cycles)),
+	}
+	for _, cycle := range cycles {
+		analysis.Cycles = append(analysis.Cycles, nodeLinks(cycle))
+	}
+	return analysis
+}
+
+// nodeLinks converts hierarchy nodes into issue links
+func nodeLinks(nodes []*HierarchyNode) []IssueLink {
+	links := []IssueLink{}
+	for _, node := range nodes {
+		links = append(links, IssueLink{
+			ID:         node.ID,
+			Repository: node.Repository,
+			Number:     node.Number,
+			Title:      node.Title,
+			State:      node.State,
+			URL:        node.URL,
+			Assignees:  node.Assignees,
+		})
+	}
+	return links
+}
+
+// formatDependencyAnalysis formats the analysis as text
+func formatDependencyAnalysis(analysis *DependencyAnalysis, repository string) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Ready to start (%d):\n", len(analysis.Ready)))
+	if len(analysis.Ready) == 0 {
+		output.WriteString("  Nothing is ready to start.\n")
+	}
+	for _, link := range analysis.Ready {
+		line := fmt.Sprintf("  %s %s", linkRef(link, repository), link.Title)
+		if len(link.Assignees) > 0 {
+			line += "   @" + strings.Join(link.Assignees, ", @")
+		}
+		output.WriteString(line + "\n")
+	}
+
+	output.WriteString(fmt.Sprintf("\nCritical path (%d issues):\n", len(analysis.CriticalPath)))
+	if len(analysis.CriticalPath) == 0 {
+		output.WriteString("  No open issues block each other.\n")
+	}
+	for i, link := range analysis.CriticalPath {
+		output.WriteString(fmt.Sprintf("  %d. %s %s\n", i+1, linkRef(link, repository), link.Title))
+	}
+
+	return output.String()
+}
+
+// formatCycle formats a dependency cycle as a chain that returns to its start
+func formatCycle(cycle []IssueLink, repository string) string {
+	refs := make([]string, 0, len(cycle)+1)
+	for _, link := range cycle {
+		refs = append(refs, linkRef(link, repository))
+	}
+	refs = append(refs, linkRef(cycle[0], repository))
+	return strings.Join(refs, " → ")
+}
+
+// runNext is the main command logic for next
+func runNext(cmd *cobra.Command, args []string) error {
+	defaultOwner, defaultRepo, err := resolveRepo(nextRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, 0)
+	if err != nil {
+		return err
+	}
+
+	analysis := analyzeDependencies(root, nextUnassignedFlag)
+
+	if nextJSONFlag {
+		data, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	repository := ref.Owner + "/" + ref.Repo
+	for _, cycle := range analysis.Cycles {
+		fmt.Fprintf(cmd.ErrOrStderr(), "! Dependency cycle: %s\n", formatCycle(cycle, repository))
+	}
+	fmt.Fprint(cmd.OutOrStdout(), formatDependencyAnalysis(analysis, repository))
+	return nil
+}
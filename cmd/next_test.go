@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"testing"
+)
+
+// dependencyTestNode builds a hierarchy node blocked by the given issue IDs
+func dependencyTestNode(id string, number int, state string, blockers ...*HierarchyNode) *HierarchyNode {
+	node := &HierarchyNode{ID: id, Repository: "owner/repo", Number: number, Title: id, State: state}
+	for _, blocker := range blockers {
+		node.BlockedBy = append(node.BlockedBy, IssueLink{
+			ID:         blocker.ID,
+			Repository: blocker.Repository,
+			Number:     blocker.Number,
+			State:      blocker.State,
+		})
+	}
+	return node
+}
+
+func linkNumbers(links []IssueLink) []int {
+	var numbers []int
+	for _, link := range links {
+		numbers = append(numbers, link.Number)
+	}
+	return numbers
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAnalyzeDependencies(t *testing.T) {
+	// design (#2) blocks api (#3) and ui (#4); api blocks release (#5).
+	// docs (#6) is done and blocks nothing open; external (#99) blocks ui.
+	design := dependencyTestNode("design", 2, "open")
+	api := dependencyTestNode("api", 3, "open", design)
+	external := &HierarchyNode{ID: "external", Repository: "other/repo", Number: 99, State: "open"}
+	ui := dependencyTestNode("ui", 4, "open", design, external)
+	release := dependencyTestNode("release", 5, "open", api)
+	docs := dependencyTestNode("docs", 6, "closed")
+	assigned := dependencyTestNode("assigned", 7, "open", docs)
+	assigned.Assignees = []string{"alice"}
+
+	backend := &HierarchyNode{ID: "backend", Number: 10, State: "open", SubIssues: []*HierarchyNode{api, release}}
+	root := &HierarchyNode{
+		ID:        "root",
+		Number:    1,
+		State:     "open",
+		SubIssues: []*HierarchyNode{design, backend, ui, docs, assigned},
+	}
+
+	analysis := analyzeDependencies(root, false)
+	if got := linkNumbers(analysis.Ready); !equalInts(got, []int{2, 7}) {
+		t.Errorf("ready = %v, want [2 7]", got)
+	}
+	if got := linkNumbers(analysis.CriticalPath); !equalInts(got, []int{2, 3, 5}) {
+		t.Errorf("critical path = %v, want [2 3 5]", got)
+	}
+	if len(analysis.Cycles) != 0 {
+		t.Errorf("unexpected cycles: %v", analysis.Cycles)
+	}
+
+	unassigned := analyzeDependencies(root, true)
+	if got := linkNumbers(unassigned.Ready); !equalInts(got, []int{2}) {
+		t.Errorf("unassigned ready = %v, want [2]", got)
+	}
+}
+
+func TestAnalyzeDependenciesCycle(t *testing.T) {
+	a := dependencyTestNode("a", 2, "open")
+	b := dependencyTestNode("b", 3, "open", a)
+	c := dependencyTestNode("c", 4, "open", b)
+	a.BlockedBy = append(a.BlockedBy, IssueLink{ID: "c", Repository: "owner/repo", Number: 4, State: "open"})
+	d := dependencyTestNode("d", 5, "open", c)
+	e := dependencyTestNode("e", 6, "open", d)
+
+	root := &HierarchyNode{ID: "root", Number: 1, State: "open", SubIssues: []*HierarchyNode{a, b, c, d, e}}
+
+	analysis := analyzeDependencies(root, false)
+	if len(analysis.Cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(analysis.Cycles))
+	}
+	if got := linkNumbers(analysis.Cycles[0]); !equalInts(got, []int{2, 3, 4}) {
+		t.Errorf("cycle = %v, want [2 3 4]", got)
+	}
+	if got := formatCycle(analysis.Cycles[0], "owner/repo"); got != "#2 → #3 → #4 → #2" {
+		t.Errorf("formatCycle() = %q", got)
+	}
+	if got := linkNumbers(analysis.CriticalPath); !equalInts(got, []int{5, 6}) {
+		t.Errorf("critical path = %v, want [5 6]", got)
+	}
+	if len(analysis.Ready) != 0 {
+		t.Errorf("expected nothing ready, got %v", linkNumbers(analysis.Ready))
+	}
+}
+
+func TestFormatDependencyAnalysis(t *testing.T) {
+	analysis := &DependencyAnalysis{
+		Ready: []IssueLink{
+			{Repository: "owner/repo", Number: 2, Title: "Design", Assignees: []string{"alice"}},
+			{Repository: "other/repo", Number: 9, Title: "Upstream fix"},
+		},
+		CriticalPath: []IssueLink{
+			{Repository: "owner/repo", Number: 2, Title: "Design"},
+			{Repository: "owner/repo", Number: 3, Title: "API"},
+		},
+	}
+
+	expected := "Ready to start (2):\n" +
+		"  #2 Design   @alice\n" +
+		"  other/repo#9 Upstream fix\n" +
+		"\nCritical path (2 issues):\n" +
+		"  1. #2 Design\n" +
+		"  2. #3 API\n"
+
+	if got := formatDependencyAnalysis(analysis, "owner/repo"); got != expected {
+		t.Errorf("formatDependencyAnalysis() output mismatch\nGot:\n%s\nExpected:\n%s", got, expected)
+	}
+}
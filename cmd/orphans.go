@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	orphansRepoFlag           string
+	orphansLabelFlag          []string
+	orphansMilestoneFlag      string
+	orphansStateFlag          string
+	orphansAuthorFlag         string
+	orphansTypeFlag           string
+	orphansLimitFlag          int
+	orphansIncludeParentsFlag bool
+	orphansAttachFlag         string
+	orphansInteractiveFlag    bool
+	orphansYesFlag            bool
+	orphansJSONFlag           bool
+)
+
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List issues that have no parent issue",
+	Long: `Find issues in a repository that are not a sub-issue of any other issue.
+
+Issues that have sub-issues of their own are top-level parents and are left out,
+unless --include-parents is given. Orphans can be attached to a parent in bulk
+with --attach, or chosen one by one with --interactive.
+
+Examples:
+  # Open issues without a parent
+  gh sub-issues orphans
+
+  # Tasks in milestone v3 that do not roll up to an epic
+  gh sub-issues orphans --type Task --milestone v3
+
+  # Attach every orphan labelled area:auth to epic #123
+  gh sub-issues orphans --label area:auth --attach 123
+
+  # Choose the orphans, and the parent, interactively
+  gh sub-issues orphans --interactive`,
+	Args: cobra.NoArgs,
+	RunE: runOrphans,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(orphansCmd)
+
+	// Add flags
+	orphansCmd.Flags().StringVarP(&orphansRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	orphansCmd.Flags().StringArrayVarP(&orphansLabelFlag, "label", "l", nil, "Only issues with this label (repeatable)")
+	orphansCmd.Flags().StringVarP(&orphansMilestoneFlag, "milestone", "m", "", "Only issues in this milestone, by title or number")
+	orphansCmd.Flags().StringVarP(&orphansStateFlag, "state", "s", "open", "Filter by state: {open|closed|all}")
+	orphansCmd.Flags().StringVarP(&orphansAuthorFlag, "author", "A", "", "Only issues created by this user")
+	orphansCmd.Flags().StringVarP(&orphansTypeFlag, "type", "t", "", "Only issues of this issue type")
+	orphansCmd.Flags().IntVarP(&orphansLimitFlag, "limit", "L", 100, "Maximum number of orphans to find")
+	orphansCmd.Flags().BoolVar(&orphansIncludeParentsFlag, "include-parents", false, "Include issues that have sub-issues of their own")
+	orphansCmd.Flags().StringVar(&orphansAttachFlag, "attach", "", "Attach the orphans as sub-issues of this issue")
+	orphansCmd.Flags().BoolVarP(&orphansInteractiveFlag, "interactive", "i", false, "Choose the orphans to attach, and the parent if --attach is not given")
+	orphansCmd.Flags().BoolVarP(&orphansYesFlag, "yes", "y", false, "Attach without asking for confirmation")
+	orphansCmd.Flags().BoolVar(&orphansJSONFlag, "json", false, "Output in JSON format")
+	orphansCmd.MarkFlagsMutuallyExclusive("json", "interactive")
+	orphansCmd.MarkFlagsMutuallyExclusive("json", "attach")
+}
+
+// OrphanIssue is an issue without a parent issue
+type OrphanIssue struct {
+	ID        string   `json:"-"`
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	State     string   `json:"state"`
+	URL       string   `json:"url"`
+	Author    string   `json:"author,omitempty"`
+	IssueType string   `json:"issueType,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+// OrphanFilters narrow down the issues scanned for orphans
+type OrphanFilters struct {
+	Labels          []string
+	MilestoneNumber int
+	State           string
+	Author          string
+	IssueType       string
+}
+
+// issueFilterVariables converts the filters into a GraphQL IssueFilters input
+func (f OrphanFilters) issueFilterVariables() map[string]interface{} {
+	filterBy := make(map[string]interface{})
+	if len(f.Labels) > 0 {
+		filterBy["labels"] = f.Labels
+	}
+	if f.MilestoneNumber > 0 {
+		filterBy["milestoneNumber"] = strconv.Itoa(f.MilestoneNumber)
+	}
+	switch f.State {
+	case "open":
+		filterBy["states"] = []string{"OPEN"}
+	case "closed":
+		filterBy["states"] = []string{"CLOSED"}
+	}
+	if f.Author != "" {
+		filterBy["createdBy"] = f.Author
+	}
+	if f.IssueType != "" {
+		filterBy["type"] = f.IssueType
+	}
+	return filterBy
+}
+
+// findOrphans scans the issues of a repository and returns those without a parent
+func findOrphans(client *api.GraphQLClient, owner, repo string, filters OrphanFilters, includeParents bool, limit int) ([]OrphanIssue, error) {
+	query := `
+		query($owner: String!, $repo: String!, $filterBy: IssueFilters, $after: String) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: 100, after: $after, filterBy: $filterBy, orderBy: {field: CREATED_AT, direction: DESC}) {
+					nodes {
+						id
+						number
+						title
+						state
+						url
+						author {
+							login
+						}
+						issueType {
+							name
+						}
+						labels(first: 20) {
+							nodes {
+								name
+							}
+						}
+						assignees(first: 10) {
+							nodes {
+								login
+							}
+						}
+						parent {
+							id
+						}
+						subIssuesSummary {
+							total
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":    owner,
+		"repo":     repo,
+		"filterBy": filters.issueFilterVariables(),
+		"after":    nil,
+	}
+
+	orphans := []OrphanIssue{}
+	for {
+		var response struct {
+			Repository struct {
+				Issues struct {
+					Nodes []struct {
+						ID     string `json:"id"`
+						Number int    `json:"number"`
+						Title  string `json:"title"`
+						State  string `json:"state"`
+						URL    string `json:"url"`
+						Author *struct {
+							Login string `json:"login"`
+						} `json:"author"`
+						IssueType *struct {
+							Name string `json:"name"`
+						} `json:"issueType"`
+						Labels struct {
+							Nodes []struct {
+								Name string `json:"name"`
+							} `json:"nodes"`
+						} `json:"labels"`
+						Assignees struct {
+							Nodes []struct {
+								Login string `json:"login"`
+							} `json:"nodes"`
+						} `json:"assignees"`
+						Parent *struct {
+							ID string `json:"id"`
+						} `json:"parent"`
+						SubIssuesSummary struct {
+							Total int `json:"total"`
+						} `json:"subIssuesSummary"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+
+		if err := client.Do(query, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to list issues in %s/%s: %w", owner, repo, err)
+		}
+
+		for _, node := range response.Repository.Issues.Nodes {
+			if node.Parent != nil || (!includeParents && node.SubIssuesSummary.Total > 0) {
+				continue
+			}
+
+			orphan := OrphanIssue{
+				ID:     node.ID,
+				Number: node.Number,
+				Title:  node.Title,
+				State:  strings.ToLower(node.State),
+				URL:    node.URL,
+			}
+			if node.Author != nil {
+				orphan.Author = node.Author.Login
+			}
+			if node.IssueType != nil {
+				orphan.IssueType = node.IssueType.Name
+			}
+			for _, label := range node.Labels.Nodes {
+				orphan.Labels = append(orphan.Labels, label.Name)
+			}
+			for _, assignee := range node.Assignees.Nodes {
+				orphan.Assignees = append(orphan.Assignees, assignee.Login)
+			}
+
+			orphans = append(orphans, orphan)
+			if len(orphans) >= limit {
+				return orphans, nil
+			}
+		}
+
+		if !response.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Repository.Issues.PageInfo.EndCursor
+	}
+
+	return orphans, nil
+}
+
+// formatOrphans formats orphans as one tab-separated line each
+func formatOrphans(orphans []OrphanIssue) string {
+	var output strings.Builder
+	for _, orphan := range orphans {
+		output.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\t%s\n",
+			orphan.Number, orphan.State, orphan.IssueType, singleLine(orphan.Title), strings.Join(orphan.Assignees, ",")))
+	}
+	return output.String()
+}
+
+// attachOrphans links orphans below a parent through the same path as add
+func attachOrphans(cmd *cobra.Command, client *api.GraphQLClient, parentRef *IssueReference, orphans []OrphanIssue) error {
+	parentID, err := getIssueNodeID(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return err
+	}
+	config, err := loadRepoConfig(parentRef.Owner, parentRef.Repo)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, orphan := range orphans {
+		if orphan.ID == parentID {
+			continue
+		}
+		subRef, err := parseIssueURL(orphan.URL)
+		if err != nil {
+			return err
+		}
+		if _, err := linkSubIssue(cmd, client, config, parentRef, parentID, subRef, false); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "✗ #%d: %v\n", orphan.Number, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to attach %d of %d issues", failed, len(orphans))
+	}
+	return nil
+}
+
+// runOrphans is the main command logic for orphans
+func runOrphans(cmd *cobra.Command, args []string) error {
+	switch orphansStateFlag {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid state: %s (expected open, closed or all)", orphansStateFlag)
+	}
+	if orphansLimitFlag <= 0 {
+		return fmt.Errorf("invalid limit: %d", orphansLimitFlag)
+	}
+	if orphansInteractiveFlag && !isInteractive() {
+		return fmt.Errorf("--interactive requires a terminal")
+	}
+
+	owner, repo, err := resolveRepo(orphansRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	var parentRef *IssueReference
+	if orphansAttachFlag != "" {
+		parentRef, err = parseIssueReference(orphansAttachFlag, owner, repo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+	}
+
+	filters := OrphanFilters{
+		Labels:    orphansLabelFlag,
+		State:     orphansStateFlag,
+		Author:    orphansAuthorFlag,
+		IssueType: orphansTypeFlag,
+	}
+	if orphansMilestoneFlag != "" {
+		filters.MilestoneNumber, err = findMilestoneNumber(owner, repo, orphansMilestoneFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Finding issues without a parent in %s/%s...\n", owner, repo)
+	orphans, err := findOrphans(client, owner, repo, filters, orphansIncludeParentsFlag, orphansLimitFlag)
+	if err != nil {
+		return err
+	}
+
+	if orphansJSONFlag {
+		data, err := json.MarshalIndent(orphans, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else if !orphansInteractiveFlag {
+		fmt.Fprint(cmd.OutOrStdout(), formatOrphans(orphans))
+	}
+
+	if len(orphans) == 0 {
+		fmt.Fprintf(cmd.OutOrStderr(), "No issues without a parent found\n")
+		return nil
+	}
+	if parentRef == nil && !orphansInteractiveFlag {
+		return nil
+	}
+
+	if orphansInteractiveFlag {
+		items := make([]PickerItem, len(orphans))
+		for i, orphan := range orphans {
+			items[i] = PickerItem{ID: orphan.ID, Number: orphan.Number, Title: orphan.Title}
+		}
+
+		scr, err := openScreen()
+		if err != nil {
+			return err
+		}
+		picked, err := runPicker(scr, "Select issues to attach", items, true)
+		scr.Close()
+		if err != nil {
+			return err
+		}
+		if len(picked) == 0 {
+			return fmt.Errorf("no issue selected")
+		}
+
+		chosen := make(map[string]bool)
+		for _, item := range picked {
+			chosen[item.ID] = true
+		}
+		var selected []OrphanIssue
+		for _, orphan := range orphans {
+			if chosen[orphan.ID] {
+				selected = append(selected, orphan)
+			}
+		}
+		orphans = selected
+
+		if parentRef == nil {
+			candidates, err := listOpenIssues(client, owner, repo, 100)
+			if err != nil {
+				return err
+			}
+			parents, err := pickIssues("Select the parent issue", candidates, false, func(c IssueCandidate) bool {
+				return !chosen[c.ID]
+			})
+			if err != nil {
+				return err
+			}
+			parentRef = &IssueReference{Owner: owner, Repo: repo, Number: parents[0].Number}
+		}
+	} else if !orphansYesFlag && dryRun == nil {
+		if !term.IsTerminal(os.Stdin) {
+			return fmt.Errorf("refusing to attach without confirmation (use --yes)")
+		}
+		ok, err := confirm(cmd, fmt.Sprintf("Attach %d issues to #%d?", len(orphans), parentRef.Number))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("attach cancelled")
+		}
+	}
+
+	return attachOrphans(cmd, client, parentRef, orphans)
+}
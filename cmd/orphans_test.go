@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrphanFilterVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters OrphanFilters
+		want    map[string]interface{}
+	}{
+		{
+			name:    "open issues by default",
+			filters: OrphanFilters{State: "open"},
+			want:    map[string]interface{}{"states": []string{"OPEN"}},
+		},
+		{
+			name:    "all states",
+			filters: OrphanFilters{State: "all"},
+			want:    map[string]interface{}{},
+		},
+		{
+			name: "all filters",
+			filters: OrphanFilters{
+				Labels:          []string{"area:auth", "bug"},
+				MilestoneNumber: 3,
+				State:           "closed",
+				Author:          "alice",
+				IssueType:       "Task",
+			},
+			want: map[string]interface{}{
+				"labels":          []string{"area:auth", "bug"},
+				"milestoneNumber": "3",
+				"states":          []string{"CLOSED"},
+				"createdBy":       "alice",
+				"type":            "Task",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filters.issueFilterVariables(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("issueFilterVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOrphans(t *testing.T) {
+	orphans := []OrphanIssue{
+		{Number: 12, Title: "Fix\tlogin", State: "open", IssueType: "Task", Assignees: []string{"alice", "bob"}},
+		{Number: 15, Title: "Untyped", State: "closed"},
+	}
+
+	want := "12\topen\tTask\tFix login\talice,bob\n" +
+		"15\tclosed\t\tUntyped\t\n"
+	if got := formatOrphans(orphans); got != want {
+		t.Errorf("formatOrphans() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// PickerItem is a selectable entry in the fuzzy picker
+type PickerItem struct {
+	ID     string
+	Number int
+	Title  string
+}
+
+// label returns the text shown and matched for an item
+func (i PickerItem) label() string {
+	return fmt.Sprintf("#%d %s", i.Number, i.Title)
+}
+
+// fuzzyScore scores how well pattern matches text as a case-insensitive subsequence.
+// Consecutive matches and matches at word starts score higher.
+func fuzzyScore(pattern, text string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+
+	score := 0
+	pi := 0
+	prev := -2
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+
+		score++
+		if prev == ti-1 {
+			score += 5
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		prev = ti
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// fuzzyFilter returns the items matching query, best matches first
+func fuzzyFilter(items []PickerItem, query string) []PickerItem {
+	type scored struct {
+		item  PickerItem
+		score int
+	}
+
+	var matches []scored
+	for _, item := range items {
+		if score, ok := fuzzyScore(query, item.label()); ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]PickerItem, len(matches))
+	for i, m := range matches {
+		result[i] = m.item
+	}
+	return result
+}
+
+// runPicker shows a fuzzy-searchable list and returns the chosen items.
+// In multi mode, space toggles items and enter confirms the selection.
+func runPicker(scr *screen, prompt string, items []PickerItem, multi bool) ([]PickerItem, error) {
+	query := ""
+	cursor := 0
+	selected := make(map[string]bool)
+
+	for {
+		matches := fuzzyFilter(items, query)
+		if cursor >= len(matches) {
+			cursor = len(matches) - 1
+		}
+		if cursor < 0 {
+			cursor = 0
+		}
+
+		_, height := scr.Size()
+		lines := []string{
+			scr.Style("1", prompt),
+			"> " + query + scr.Style("7", " "),
+			"",
+		}
+
+		visible := height - len(lines) - 2
+		if visible < 1 {
+			visible = 1
+		}
+		offset := 0
+		if cursor >= visible {
+			offset = cursor - visible + 1
+		}
+
+		for i := offset; i < len(matches) && i < offset+visible; i++ {
+			mark := "  "
+			if multi {
+				mark = "[ ] "
+				if selected[matches[i].ID] {
+					mark = "[x] "
+				}
+			}
+			line := mark + matches[i].label()
+			if i == cursor {
+				line = scr.Style("7", "> "+line)
+			} else {
+				line = "  " + line
+			}
+			lines = append(lines, line)
+		}
+		if len(matches) == 0 {
+			lines = append(lines, scr.Style("2", "  No matching issues"))
+		}
+
+		help := "enter: select  esc: cancel"
+		if multi {
+			help = fmt.Sprintf("space: toggle  enter: confirm (%d selected)  esc: cancel", len(selected))
+		}
+		for len(lines) < height-1 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, scr.Style("2", help))
+		scr.Draw(lines)
+
+		ev, err := scr.ReadKey()
+		if err != nil {
+			return nil, err
+		}
+
+		switch ev.Key {
+		case KeyEscape, KeyCtrlC:
+			return nil, nil
+		case KeyUp:
+			cursor--
+		case KeyDown:
+			cursor++
+		case KeyBackspace:
+			if r := []rune(query); len(r) > 0 {
+				query = string(r[:len(r)-1])
+			}
+		case KeyEnter:
+			if !multi {
+				if len(matches) == 0 {
+					continue
+				}
+				return []PickerItem{matches[cursor]}, nil
+			}
+			if len(selected) == 0 && len(matches) > 0 {
+				selected[matches[cursor].ID] = true
+			}
+			var result []PickerItem
+			for _, item := range items {
+				if selected[item.ID] {
+					result = append(result, item)
+				}
+			}
+			return result, nil
+		case KeyRune:
+			if multi && ev.Rune == ' ' {
+				if len(matches) > 0 {
+					id := matches[cursor].ID
+					selected[id] = !selected[id]
+				}
+				continue
+			}
+			query += string(ev.Rune)
+			cursor = 0
+		}
+	}
+}
+
+// IssueCandidate is an open issue that may be picked as parent or sub-issue
+type IssueCandidate struct {
+	PickerItem
+	HasParent bool
+}
+
+// listOpenIssues fetches recently updated open issues in a repository
+func listOpenIssues(client *api.GraphQLClient, owner, repo string, limit int) ([]IssueCandidate, error) {
+	query := `
+		query($owner: String!, $repo: String!, $limit: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: $limit, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+					nodes {
+						id
+						number
+						title
+						parent {
+							id
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+		"limit": limit,
+	}
+
+	var response struct {
+		Repository struct {
+			Issues struct {
+				Nodes []struct {
+					ID     string `json:"id"`
+					Number int    `json:"number"`
+					Title  string `json:"title"`
+					Parent *struct {
+						ID string `json:"id"`
+					} `json:"parent"`
+				} `json:"nodes"`
+			} `json:"issues"`
+		} `json:"repository"`
+	}
+
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to list issues in %s/%s: %w", owner, repo, err)
+	}
+
+	var issues []IssueCandidate
+	for _, node := range response.Repository.Issues.Nodes {
+		issues = append(issues, IssueCandidate{
+			PickerItem: PickerItem{ID: node.ID, Number: node.Number, Title: node.Title},
+			HasParent:  node.Parent != nil,
+		})
+	}
+	return issues, nil
+}
+
+// isInteractive reports whether prompts can be shown. It is a variable so
+// tests can decide whether a terminal is attached.
+var isInteractive = func() bool {
+	return term.IsTerminal(os.Stdin) && term.IsTerminal(os.Stdout)
+}
+
+// exactArgsOrInteractive accepts no arguments in an interactive terminal, and exactly n otherwise
+func exactArgsOrInteractive(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && isInteractive() {
+			return nil
+		}
+		return cobra.ExactArgs(n)(cmd, args)
+	}
+}
+
+// pickIssues shows a picker over open issues and returns the chosen ones
+func pickIssues(prompt string, candidates []IssueCandidate, multi bool, include func(IssueCandidate) bool) ([]PickerItem, error) {
+	var items []PickerItem
+	for _, c := range candidates {
+		if include == nil || include(c) {
+			items = append(items, c.PickerItem)
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no matching open issues to choose from")
+	}
+
+	scr, err := openScreen()
+	if err != nil {
+		return nil, err
+	}
+	picked, err := runPicker(scr, prompt, items, multi)
+	scr.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(picked) == 0 {
+		return nil, fmt.Errorf("no issue selected")
+	}
+	return picked, nil
+}
+
+// pickAddIssues picks a parent issue and then the issues to add as its sub-issues
+func pickAddIssues(client *api.GraphQLClient, owner, repo string) (*IssueReference, []*IssueReference, error) {
+	candidates, err := listOpenIssues(client, owner, repo, 100)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parents, err := pickIssues("Select the parent issue", candidates, false, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent := parents[0]
+
+	children, err := pickIssues(fmt.Sprintf("Select sub-issues to add to #%d", parent.Number), candidates, true,
+		func(c IssueCandidate) bool {
+			return !c.HasParent && c.ID != parent.ID
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parentRef := &IssueReference{Owner: owner, Repo: repo, Number: parent.Number}
+	var subRefs []*IssueReference
+	for _, child := range children {
+		subRefs = append(subRefs, &IssueReference{Owner: owner, Repo: repo, Number: child.Number})
+	}
+	return parentRef, subRefs, nil
+}
+
+// pickListParent picks the parent issue to list sub-issues for
+func pickListParent(owner, repo string) (*IssueReference, error) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	candidates, err := listOpenIssues(client, owner, repo, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	picked, err := pickIssues("Select the parent issue", candidates, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IssueReference{Owner: owner, Repo: repo, Number: picked[0].Number}, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		match   bool
+	}{
+		{"", "anything", true},
+		{"login", "#12 Add login endpoint", true},
+		{"LGN", "#12 Add login endpoint", true},
+		{"12", "#12 Add login endpoint", true},
+		{"xyz", "#12 Add login endpoint", false},
+		{"endpointlogin", "#12 Add login endpoint", false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.pattern, tt.text); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) match = %v, want %v", tt.pattern, tt.text, ok, tt.match)
+		}
+	}
+
+	consecutive, _ := fuzzyScore("log", "#1 login")
+	scattered, _ := fuzzyScore("log", "#1 lazy old grid")
+	if consecutive <= scattered {
+		t.Errorf("consecutive match score %d should be higher than scattered %d", consecutive, scattered)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	items := []PickerItem{
+		{ID: "A", Number: 1, Title: "Lazy old grid"},
+		{ID: "B", Number: 2, Title: "Login page"},
+		{ID: "C", Number: 3, Title: "Documentation"},
+	}
+
+	result := fuzzyFilter(items, "log")
+	if len(result) != 2 {
+		t.Fatalf("fuzzyFilter() returned %d items, want 2", len(result))
+	}
+	if result[0].ID != "B" {
+		t.Errorf("best match = %s, want B", result[0].ID)
+	}
+
+	if all := fuzzyFilter(items, ""); len(all) != len(items) {
+		t.Errorf("empty query returned %d items, want %d", len(all), len(items))
+	}
+}
+
+func TestExactArgsOrInteractive(t *testing.T) {
+	defer func(original func() bool) { isInteractive = original }(isInteractive)
+	validate := exactArgsOrInteractive(2)
+
+	t.Run("without a terminal", func(t *testing.T) {
+		isInteractive = func() bool { return false }
+
+		if err := validate(nil, []string{}); err == nil {
+			t.Errorf("expected error for missing arguments in a non-interactive context")
+		}
+		if err := validate(nil, []string{"1"}); err == nil {
+			t.Errorf("expected error for one argument")
+		}
+		if err := validate(nil, []string{"1", "2"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("in a terminal", func(t *testing.T) {
+		isInteractive = func() bool { return true }
+
+		if err := validate(nil, []string{}); err != nil {
+			t.Errorf("expected no arguments to be accepted for the picker, got %v", err)
+		}
+		if err := validate(nil, []string{"1"}); err == nil {
+			t.Errorf("expected error for one argument")
+		}
+		if err := validate(nil, []string{"1", "2"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,773 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// ProjectRef identifies a Projects (v2) board by owner login and number
+type ProjectRef struct {
+	Owner  string
+	Number int
+}
+
+// parseProjectRef parses a project number or OWNER/NUMBER, defaulting the owner
+func parseProjectRef(value, defaultOwner string) (*ProjectRef, error) {
+	owner := defaultOwner
+	number := value
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		owner = value[:i]
+		number = value[i+1:]
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 || owner == "" {
+		return nil, fmt.Errorf("invalid project: %s (expected NUMBER or OWNER/NUMBER)", value)
+	}
+	return &ProjectRef{Owner: owner, Number: n}, nil
+}
+
+// FieldAssignment is a NAME=VALUE pair for a project field
+type FieldAssignment struct {
+	Name  string
+	Value string
+}
+
+// parseFieldAssignments parses NAME=VALUE pairs
+func parseFieldAssignments(values []string) ([]FieldAssignment, error) {
+	var assignments []FieldAssignment
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field %q (expected NAME=VALUE)", value)
+		}
+		assignments = append(assignments, FieldAssignment{Name: name, Value: strings.TrimSpace(v)})
+	}
+	return assignments, nil
+}
+
+// matchesFieldFilters reports whether project field values match all filters, ignoring case
+func matchesFieldFilters(values map[string]string, filters []FieldAssignment) bool {
+	for _, filter := range filters {
+		matched := false
+		for name, value := range values {
+			if strings.EqualFold(name, filter.Name) && strings.EqualFold(value, filter.Value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// projectFieldValueFields select the displayable value of a project item field
+const projectFieldValueFields = `
+	... on ProjectV2ItemFieldSingleSelectValue {
+		name
+		field {
+			... on ProjectV2FieldCommon {
+				name
+			}
+		}
+	}
+	... on ProjectV2ItemFieldIterationValue {
+		title
+		field {
+			... on ProjectV2FieldCommon {
+				name
+			}
+		}
+	}
+	... on ProjectV2ItemFieldNumberValue {
+		number
+		field {
+			... on ProjectV2FieldCommon {
+				name
+			}
+		}
+	}
+	... on ProjectV2ItemFieldDateValue {
+		date
+		field {
+			... on ProjectV2FieldCommon {
+				name
+			}
+		}
+	}`
+
+// projectFieldValue is the GraphQL shape of projectFieldValueFields
+type projectFieldValue struct {
+	Name   *string  `json:"name"`
+	Title  *string  `json:"title"`
+	Number *float64 `json:"number"`
+	Date   *string  `json:"date"`
+	Field  struct {
+		Name string `json:"name"`
+	} `json:"field"`
+}
+
+// display returns the field name and its value as text
+func (v projectFieldValue) display() (string, string, bool) {
+	switch {
+	case v.Field.Name == "":
+		return "", "", false
+	case v.Name != nil:
+		return v.Field.Name, *v.Name, true
+	case v.Title != nil:
+		return v.Field.Name, *v.Title, true
+	case v.Number != nil:
+		return v.Field.Name, strconv.FormatFloat(*v.Number, 'f', -1, 64), true
+	case v.Date != nil:
+		return v.Field.Name, *v.Date, true
+	}
+	return "", "", false
+}
+
+// getProjectFieldValues fetches the project field values of issues in one project.
+// It returns the values by issue ID and the field names in the order they were first seen.
+func getProjectFieldValues(client *api.GraphQLClient, issueIDs []string, project *ProjectRef) (map[string]map[string]string, []string, error) {
+	query := `
+		query($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				... on Issue {
+					id
+					projectItems(first: 20) {
+						nodes {
+							project {
+								number
+								owner {
+									... on Organization {
+										login
+									}
+									... on User {
+										login
+									}
+								}
+							}
+							fieldValues(first: 50) {
+								nodes {` + projectFieldValueFields + `
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	values := make(map[string]map[string]string)
+	var names []string
+	seen := make(map[string]bool)
+
+	for start := 0; start < len(issueIDs); start += 100 {
+		end := min(start+100, len(issueIDs))
+
+		var response struct {
+			Nodes []struct {
+				ID           string `json:"id"`
+				ProjectItems struct {
+					Nodes []struct {
+						Project struct {
+							Number int `json:"number"`
+							Owner  struct {
+								Login string `json:"login"`
+							} `json:"owner"`
+						} `json:"project"`
+						FieldValues struct {
+							Nodes []projectFieldValue `json:"nodes"`
+						} `json:"fieldValues"`
+					} `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"nodes"`
+		}
+
+		variables := map[string]interface{}{
+			"ids": issueIDs[start:end],
+		}
+		if err := client.Do(query, variables, &response); err != nil {
+			return nil, nil, fmt.Errorf("failed to get project fields: %w", err)
+		}
+
+		for _, node := range response.Nodes {
+			for _, item := range node.ProjectItems.Nodes {
+				if item.Project.Number != project.Number || !strings.EqualFold(item.Project.Owner.Login, project.Owner) {
+					continue
+				}
+				fields := make(map[string]string)
+				for _, value := range item.FieldValues.Nodes {
+					name, text, ok := value.display()
+					if !ok {
+						continue
+					}
+					fields[name] = text
+					if !seen[name] {
+						seen[name] = true
+						names = append(names, name)
+					}
+				}
+				values[node.ID] = fields
+			}
+		}
+	}
+
+	return values, names, nil
+}
+
+// ProjectField is a field definition of a project
+type ProjectField struct {
+	ID         string
+	Name       string
+	DataType   string
+	Options    []ProjectFieldOption
+	Iterations []ProjectIteration
+}
+
+// ProjectFieldOption is an option of a single-select field
+type ProjectFieldOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProjectIteration is an iteration of an iteration field
+type ProjectIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+// Project is a Projects (v2) board with its fields
+type Project struct {
+	ID     string
+	Title  string
+	Fields []ProjectField
+}
+
+// getProject fetches a project and its field definitions
+func getProject(client *api.GraphQLClient, ref *ProjectRef) (*Project, error) {
+	query := `
+		query($owner: String!, $number: Int!) {
+			repositoryOwner(login: $owner) {
+				... on ProjectV2Owner {
+					projectV2(number: $number) {
+						id
+						title
+						fields(first: 50) {
+							nodes {
+								... on ProjectV2FieldCommon {
+									id
+									name
+									dataType
+								}
+								... on ProjectV2SingleSelectField {
+									options {
+										id
+										name
+									}
+								}
+								... on ProjectV2IterationField {
+									configuration {
+										iterations {
+											id
+											title
+											startDate
+											duration
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  ref.Owner,
+		"number": ref.Number,
+	}
+
+	var response struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				ID     string `json:"id"`
+				Title  string `json:"title"`
+				Fields struct {
+					Nodes []struct {
+						ID            string               `json:"id"`
+						Name          string               `json:"name"`
+						DataType      string               `json:"dataType"`
+						Options       []ProjectFieldOption `json:"options"`
+						Configuration struct {
+							Iterations []ProjectIteration `json:"iterations"`
+						} `json:"configuration"`
+					} `json:"nodes"`
+				} `json:"fields"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get project %s/%d: %w", ref.Owner, ref.Number, err)
+	}
+	if response.RepositoryOwner == nil || response.RepositoryOwner.ProjectV2 == nil {
+		return nil, fmt.Errorf("project %d not found for %s", ref.Number, ref.Owner)
+	}
+
+	p := response.RepositoryOwner.ProjectV2
+	project := &Project{ID: p.ID, Title: p.Title}
+	for _, f := range p.Fields.Nodes {
+		if f.ID == "" {
+			continue
+		}
+		project.Fields = append(project.Fields, ProjectField{
+			ID:         f.ID,
+			Name:       f.Name,
+			DataType:   f.DataType,
+			Options:    f.Options,
+			Iterations: f.Configuration.Iterations,
+		})
+	}
+	return project, nil
+}
+
+// fieldValueInput converts a text value into the value input of a project field.
+// Iteration fields accept "@current" for the iteration that contains today.
+func fieldValueInput(field ProjectField, value string, today time.Time) (map[string]interface{}, error) {
+	switch field.DataType {
+	case "SINGLE_SELECT":
+		for _, option := range field.Options {
+			if strings.EqualFold(option.Name, value) {
+				return map[string]interface{}{"singleSelectOptionId": option.ID}, nil
+			}
+		}
+		var names []string
+		for _, option := range field.Options {
+			names = append(names, option.Name)
+		}
+		return nil, fmt.Errorf("unknown option %q for field %s (available: %s)", value, field.Name, strings.Join(names, ", "))
+
+	case "ITERATION":
+		for _, iteration := range field.Iterations {
+			if value == "@current" {
+				start, err := time.Parse("2006-01-02", iteration.StartDate)
+				if err != nil {
+					continue
+				}
+				end := start.AddDate(0, 0, iteration.Duration)
+				if !today.Before(start) && today.Before(end) {
+					return map[string]interface{}{"iterationId": iteration.ID}, nil
+				}
+			} else if strings.EqualFold(iteration.Title, value) {
+				return map[string]interface{}{"iterationId": iteration.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown iteration %q for field %s", value, field.Name)
+
+	case "NUMBER":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for field %s", value, field.Name)
+		}
+		return map[string]interface{}{"number": n}, nil
+
+	case "DATE":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("invalid date %q for field %s (expected YYYY-MM-DD)", value, field.Name)
+		}
+		return map[string]interface{}{"date": value}, nil
+
+	case "TEXT":
+		return map[string]interface{}{"text": value}, nil
+	}
+
+	return nil, fmt.Errorf("field %s of type %s cannot be set", field.Name, field.DataType)
+}
+
+// ProjectFieldUpdate is a resolved field value to set on a project item
+type ProjectFieldUpdate struct {
+	FieldID   string
+	FieldName string
+	Value     map[string]interface{}
+	Display   string
+}
+
+// resolveFieldAssignments resolves NAME=VALUE pairs against the fields of a project
+func resolveFieldAssignments(project *Project, assignments []FieldAssignment, today time.Time) ([]ProjectFieldUpdate, error) {
+	var updates []ProjectFieldUpdate
+	for _, assignment := range assignments {
+		var field *ProjectField
+		for i := range project.Fields {
+			if strings.EqualFold(project.Fields[i].Name, assignment.Name) {
+				field = &project.Fields[i]
+				break
+			}
+		}
+		if field == nil {
+			return nil, fmt.Errorf("field %q not found in project %s", assignment.Name, project.Title)
+		}
+
+		value, err := fieldValueInput(*field, assignment.Value, today)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, ProjectFieldUpdate{FieldID: field.ID, FieldName: field.Name, Value: value, Display: assignment.Value})
+	}
+	return updates, nil
+}
+
+// addProjectItem adds an issue to a project and returns the project item ID
+func addProjectItem(client *api.GraphQLClient, project ProjectSummary, contentID string) (string, error) {
+	mutation := `
+		mutation($projectId: ID!, $contentId: ID!) {
+			addProjectV2ItemById(input: {
+				projectId: $projectId,
+				contentId: $contentId
+			}) {
+				item {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"projectId": project.ID,
+		"contentId": contentID,
+	}
+	if recordMutation("addProjectItem", variables, fmt.Sprintf("Add %s to %s", contentID, project.ID), contentID, project.ID) {
+		return planNode(contentID, contentID), nil
+	}
+
+	// Adding an issue that is already in the project returns its item, which
+	// must not be removed when undoing
+	added := createdInRun(contentID)
+	if !added {
+		projects, err := getIssueProjects(client, []string{contentID})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "! Failed to look up issues for the journal: %v\n", err)
+		}
+		added = err == nil && !slices.ContainsFunc(projects[contentID], func(p ProjectSummary) bool { return p.ID == project.ID })
+	}
+
+	var response struct {
+		AddProjectV2ItemByID struct {
+			Item struct {
+				ID string `json:"id"`
+			} `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return "", fmt.Errorf("failed to add issue to project: %w", err)
+	}
+
+	itemID := response.AddProjectV2ItemByID.Item.ID
+	if added {
+		journal.mu.Lock()
+		if journal.items == nil {
+			journal.items = make(map[string]bool)
+		}
+		journal.items[itemID] = true
+		journal.mu.Unlock()
+		writeJournal(JournalEntry{Action: journalProject, Issue: journalIssues(client, contentID)[contentID], Project: &project, Item: itemID})
+	}
+	return itemID, nil
+}
+
+// deleteProjectItem removes an issue's item from a project
+func deleteProjectItem(client *api.GraphQLClient, project ProjectSummary, itemID, contentID string) error {
+	mutation := `
+		mutation($projectId: ID!, $itemId: ID!) {
+			deleteProjectV2Item(input: {
+				projectId: $projectId,
+				itemId: $itemId
+			}) {
+				deletedItemId
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"projectId": project.ID,
+		"itemId":    itemID,
+	}
+	if recordMutation("deleteProjectItem", variables, fmt.Sprintf("Remove %s from %s", contentID, project.ID), contentID, project.ID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to remove issue from project: %w", err)
+	}
+	writeJournal(JournalEntry{Action: journalUnproject, Issue: journalIssues(client, contentID)[contentID], Project: &project, Item: itemID})
+
+	return nil
+}
+
+// updateProjectItemField sets a field value of a project item
+func updateProjectItemField(client *api.GraphQLClient, projectID, itemID string, update ProjectFieldUpdate) error {
+	mutation := `
+		mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
+			updateProjectV2ItemFieldValue(input: {
+				projectId: $projectId,
+				itemId: $itemId,
+				fieldId: $fieldId,
+				value: $value
+			}) {
+				projectV2Item {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   update.FieldID,
+		"value":     update.Value,
+	}
+	if recordMutation("updateProjectItemField", variables,
+		fmt.Sprintf("Set %s of %s in %s to %q", update.FieldName, itemID, projectID, update.Display), itemID, projectID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to set project field %s: %w", update.FieldName, err)
+	}
+
+	// Only items added by this run are known to have had no value before
+	journal.mu.Lock()
+	var previous *string
+	if journal.items[itemID] {
+		previous = new(string)
+	}
+	journal.mu.Unlock()
+	writeJournal(JournalEntry{
+		Action:   journalField,
+		Project:  &ProjectSummary{ID: projectID},
+		Item:     itemID,
+		Field:    update.FieldName,
+		FieldID:  update.FieldID,
+		Values:   []string{update.Display},
+		Previous: previous,
+	})
+
+	return nil
+}
+
+// clearProjectItemField clears a field value of a project item
+func clearProjectItemField(client *api.GraphQLClient, projectID, itemID, fieldID, fieldName string) error {
+	mutation := `
+		mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!) {
+			clearProjectV2ItemFieldValue(input: {
+				projectId: $projectId,
+				itemId: $itemId,
+				fieldId: $fieldId
+			}) {
+				projectV2Item {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+	}
+	if recordMutation("clearProjectItemField", variables,
+		fmt.Sprintf("Clear %s of %s in %s", fieldName, itemID, projectID), itemID, projectID) {
+		return nil
+	}
+
+	var response struct{}
+	if err := client.Do(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to clear project field %s: %w", fieldName, err)
+	}
+	writeJournal(JournalEntry{Action: journalField, Project: &ProjectSummary{ID: projectID}, Item: itemID, Field: fieldName, FieldID: fieldID})
+
+	return nil
+}
+
+// applyProjectFields attaches project field values to sub-issues and keeps up
+// to limit of those matching the filters
+func applyProjectFields(result *ListResult, values map[string]map[string]string, names []string, filters []FieldAssignment, limit int) {
+	kept := []SubIssue{}
+	result.Total = 0
+	result.OpenCount = 0
+	for _, issue := range result.SubIssues {
+		issue.ProjectFields = values[issue.ID]
+		if !matchesFieldFilters(issue.ProjectFields, filters) {
+			continue
+		}
+		if len(kept) == limit {
+			break
+		}
+		kept = append(kept, issue)
+		result.Total++
+		if issue.State == "open" {
+			result.OpenCount++
+		}
+	}
+	result.SubIssues = kept
+	result.ProjectFields = names
+}
+
+// resolveProjectFields fetches a project and resolves field values to set on its items
+func resolveProjectFields(client *api.GraphQLClient, projectFlag, defaultOwner string, fieldFlags []string) (*Project, []ProjectFieldUpdate, error) {
+	ref, err := parseProjectRef(projectFlag, defaultOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignments, err := parseFieldAssignments(fieldFlags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, err := getProject(client, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	updates, err := resolveFieldAssignments(project, assignments, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return project, updates, nil
+}
+
+// resolveParentProject resolves the project to add the sub-issues of a parent
+// to and the field values to set. The parent must belong to the given project;
+// without one, the parent's only project is used.
+func resolveParentProject(client *api.GraphQLClient, projectFlag string, parentRef *IssueReference, parentID string, fieldFlags []string) (*Project, []ProjectFieldUpdate, error) {
+	projects, err := getIssueProjects(client, []string{parentID})
+	if err != nil {
+		return nil, nil, err
+	}
+	parentProjects := projects[parentID]
+
+	if projectFlag == "" {
+		switch len(parentProjects) {
+		case 0:
+			return nil, nil, fmt.Errorf("issue #%d is not in a project (use --project)", parentRef.Number)
+		case 1:
+			projectFlag = fmt.Sprintf("%s/%d", parentProjects[0].Owner, parentProjects[0].Number)
+		default:
+			var titles []string
+			for _, p := range parentProjects {
+				titles = append(titles, fmt.Sprintf("%s (%s/%d)", p.Title, p.Owner, p.Number))
+			}
+			return nil, nil, fmt.Errorf("issue #%d is in several projects, choose one with --project: %s",
+				parentRef.Number, strings.Join(titles, ", "))
+		}
+	}
+
+	project, updates, err := resolveProjectFields(client, projectFlag, parentRef.Owner, fieldFlags)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range parentProjects {
+		if p.ID == project.ID {
+			return project, updates, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("issue #%d is not in project %s", parentRef.Number, project.Title)
+}
+
+// addToProject adds an issue to a project and sets the given field values
+func addToProject(cmd *cobra.Command, client *api.GraphQLClient, project *Project, updates []ProjectFieldUpdate, issueID string, number int) error {
+	itemID, err := addProjectItem(client, ProjectSummary{ID: project.ID, Title: project.Title}, issueID)
+	if err != nil {
+		return err
+	}
+	for _, update := range updates {
+		if err := updateProjectItemField(client, project.ID, itemID, update); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Added issue #%d to project %s\n", number, project.Title)
+	return nil
+}
+
+// ProjectSummary identifies a project an issue belongs to
+type ProjectSummary struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Number int    `json:"number"`
+	Owner  string `json:"-"`
+}
+
+// getIssueProjects fetches the projects each issue belongs to, by issue ID
+func getIssueProjects(client *api.GraphQLClient, issueIDs []string) (map[string][]ProjectSummary, error) {
+	query := `
+		query($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				... on Issue {
+					id
+					projectItems(first: 20) {
+						nodes {
+							project {
+								id
+								title
+								number
+								owner {
+									... on Organization {
+										login
+									}
+									... on User {
+										login
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	projects := make(map[string][]ProjectSummary)
+	for start := 0; start < len(issueIDs); start += 100 {
+		end := min(start+100, len(issueIDs))
+
+		var response struct {
+			Nodes []struct {
+				ID           string `json:"id"`
+				ProjectItems struct {
+					Nodes []struct {
+						Project struct {
+							ProjectSummary
+							Owner struct {
+								Login string `json:"login"`
+							} `json:"owner"`
+						} `json:"project"`
+					} `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"nodes"`
+		}
+
+		variables := map[string]interface{}{
+			"ids": issueIDs[start:end],
+		}
+		if err := client.Do(query, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to get projects: %w", err)
+		}
+
+		for _, node := range response.Nodes {
+			for _, item := range node.ProjectItems.Nodes {
+				project := item.Project.ProjectSummary
+				project.Owner = item.Project.Owner.Login
+				projects[node.ID] = append(projects[node.ID], project)
+			}
+		}
+	}
+	return projects, nil
+}
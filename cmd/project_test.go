@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseProjectRef(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantOwner string
+		wantNum   int
+		wantErr   bool
+	}{
+		{"4", "owner", 4, false},
+		{"my-org/12", "my-org", 12, false},
+		{"abc", "", 0, true},
+		{"org/0", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		ref, err := parseProjectRef(tt.input, "owner")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseProjectRef(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (ref.Owner != tt.wantOwner || ref.Number != tt.wantNum) {
+			t.Errorf("parseProjectRef(%q) = %+v, want %s/%d", tt.input, ref, tt.wantOwner, tt.wantNum)
+		}
+	}
+}
+
+func TestParseFieldAssignments(t *testing.T) {
+	got, err := parseFieldAssignments([]string{"Status=In Progress", " Estimate = 3 ", "Note="})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []FieldAssignment{{"Status", "In Progress"}, {"Estimate", "3"}, {"Note", ""}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("assignment %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := parseFieldAssignments([]string{"Status"}); err == nil {
+		t.Error("expected error for missing value")
+	}
+}
+
+func TestFieldValueInput(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	status := ProjectField{Name: "Status", DataType: "SINGLE_SELECT", Options: []ProjectFieldOption{{ID: "opt1", Name: "Todo"}, {ID: "opt2", Name: "Done"}}}
+	sprint := ProjectField{Name: "Sprint", DataType: "ITERATION", Iterations: []ProjectIteration{
+		{ID: "it1", Title: "Sprint 1", StartDate: "2026-02-23", Duration: 14},
+		{ID: "it2", Title: "Sprint 2", StartDate: "2026-03-09", Duration: 14},
+	}}
+
+	tests := []struct {
+		name    string
+		field   ProjectField
+		value   string
+		key     string
+		want    interface{}
+		wantErr bool
+	}{
+		{"single select", status, "done", "singleSelectOptionId", "opt2", false},
+		{"unknown option", status, "Blocked", "", nil, true},
+		{"iteration by title", sprint, "Sprint 1", "iterationId", "it1", false},
+		{"current iteration", sprint, "@current", "iterationId", "it2", false},
+		{"number", ProjectField{Name: "Estimate", DataType: "NUMBER"}, "2.5", "number", 2.5, false},
+		{"bad number", ProjectField{Name: "Estimate", DataType: "NUMBER"}, "two", "", nil, true},
+		{"date", ProjectField{Name: "Due", DataType: "DATE"}, "2026-04-01", "date", "2026-04-01", false},
+		{"bad date", ProjectField{Name: "Due", DataType: "DATE"}, "April 1", "", nil, true},
+		{"unsupported", ProjectField{Name: "Repository", DataType: "REPOSITORY"}, "x", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fieldValueInput(tt.field, tt.value, today)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fieldValueInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got[tt.key] != tt.want {
+				t.Errorf("fieldValueInput() = %v, want %s: %v", got, tt.key, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyProjectFields(t *testing.T) {
+	result := &ListResult{
+		SubIssues: []SubIssue{
+			{ID: "A", Number: 2, State: "open"},
+			{ID: "B", Number: 3, State: "closed"},
+			{ID: "C", Number: 4, State: "open"},
+		},
+		Total:     3,
+		OpenCount: 2,
+	}
+	values := map[string]map[string]string{
+		"A": {"Status": "In Progress", "Estimate": "3"},
+		"B": {"Status": "Done"},
+	}
+
+	applyProjectFields(result, values, []string{"Status", "Estimate"}, []FieldAssignment{{"status", "in progress"}}, 30)
+
+	if len(result.SubIssues) != 1 || result.SubIssues[0].Number != 2 {
+		t.Fatalf("expected only #2 to match, got %+v", result.SubIssues)
+	}
+	if result.Total != 1 || result.OpenCount != 1 {
+		t.Errorf("counts = %d total, %d open, want 1 and 1", result.Total, result.OpenCount)
+	}
+	if result.SubIssues[0].ProjectFields["Estimate"] != "3" {
+		t.Errorf("expected estimate to be attached, got %v", result.SubIssues[0].ProjectFields)
+	}
+
+	output := formatTTY(result, 80, &colorScheme{})
+	if !containsString(output, "ISSUE  TITLE       STATE   STATUS       ESTIMATE") {
+		t.Errorf("formatTTY() missing project columns\nFull output:\n%s", output)
+	}
+
+	// The limit applies to the matches, not to the fetched sub-issues
+	result = &ListResult{SubIssues: []SubIssue{
+		{ID: "A", Number: 2, State: "open"},
+		{ID: "B", Number: 3, State: "open"},
+		{ID: "C", Number: 4, State: "open"},
+		{ID: "D", Number: 5, State: "closed"},
+	}}
+	values = map[string]map[string]string{"B": {"Status": "Todo"}, "C": {"Status": "Todo"}, "D": {"Status": "Todo"}}
+	applyProjectFields(result, values, []string{"Status"}, []FieldAssignment{{"Status", "Todo"}}, 2)
+	if len(result.SubIssues) != 2 || result.SubIssues[0].Number != 3 || result.SubIssues[1].Number != 4 {
+		t.Errorf("expected #3 and #4 within the limit, got %+v", result.SubIssues)
+	}
+}
@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	propagateRepoFlag        string
+	propagateMilestoneFlag   bool
+	propagateLabelsFlag      bool
+	propagateLabelFlag       []string
+	propagateAssigneesFlag   bool
+	propagateProjectFlag     bool
+	propagateTypeFlag        bool
+	propagateStateFlag       string
+	propagateDepthFlag       int
+	propagateWithLabelFlag   []string
+	propagateYesFlag         bool
+	propagateConcurrencyFlag int
+)
+
+var propagateCmd = &cobra.Command{
+	Use:   "propagate <parent-issue>",
+	Short: "Copy milestone, labels, assignees, projects or type from a parent to its descendants",
+	Long: `Copy selected attributes of a parent issue to all of its descendants.
+
+Choose what to copy with flags. Labels and assignees are added, never replaced;
+use --label -NAME to remove a label from every descendant. A preview of the
+changes is shown before anything is modified.
+
+Examples:
+  # Move every open descendant to the parent's milestone
+  gh sub-issues propagate 123 --milestone
+
+  # Copy the parent's labels and remove "needs-triage"
+  gh sub-issues propagate 123 --labels --label -needs-triage
+
+  # Copy assignees and projects to direct children only
+  gh sub-issues propagate 123 --assignees --project --depth 1
+
+  # Preview only
+  gh sub-issues propagate 123 --milestone --type --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPropagate,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(propagateCmd)
+
+	// Add flags
+	propagateCmd.Flags().StringVarP(&propagateRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	propagateCmd.Flags().BoolVar(&propagateMilestoneFlag, "milestone", false, "Copy the parent's milestone")
+	propagateCmd.Flags().BoolVar(&propagateLabelsFlag, "labels", false, "Add the parent's labels")
+	propagateCmd.Flags().StringArrayVar(&propagateLabelFlag, "label", nil, "Add (+NAME or NAME) or remove (-NAME) a label (repeatable)")
+	propagateCmd.Flags().BoolVar(&propagateAssigneesFlag, "assignees", false, "Add the parent's assignees")
+	propagateCmd.Flags().BoolVar(&propagateProjectFlag, "project", false, "Add descendants to the parent's projects")
+	propagateCmd.Flags().BoolVar(&propagateTypeFlag, "type", false, "Copy the parent's issue type")
+	propagateCmd.Flags().StringVarP(&propagateStateFlag, "state", "s", "open", "Only update descendants in this state: {open|closed|all}")
+	propagateCmd.Flags().IntVar(&propagateDepthFlag, "depth", 0, "Maximum depth of descendants to update (0 for all)")
+	propagateCmd.Flags().StringArrayVar(&propagateWithLabelFlag, "with-label", nil, "Only update descendants that have this label (repeatable)")
+	propagateCmd.Flags().BoolVarP(&propagateYesFlag, "yes", "y", false, "Apply without asking for confirmation")
+	propagateCmd.Flags().IntVar(&propagateConcurrencyFlag, "concurrency", 4, "Maximum number of issues updated in parallel")
+}
+
+// PropagateOptions selects what to copy and to which descendants
+type PropagateOptions struct {
+	Milestone    bool
+	AddLabels    []string
+	RemoveLabels []string
+	Assignees    bool
+	Projects     []ProjectSummary
+	Type         bool
+	State        string
+	Depth        int
+	WithLabels   []string
+}
+
+// PropagateChange is the set of changes to one descendant
+type PropagateChange struct {
+	Node            *HierarchyNode
+	Milestone       string
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	AddProjects     []ProjectSummary
+	IssueType       string
+	IssueTypeID     string
+	OldMilestone    string
+	OldIssueType    string
+	changeMilestone bool
+	changeType      bool
+
+	// MilestoneNumber is the number of the milestone in the issue's repository,
+	// and SkipMilestone why the milestone cannot be set there
+	MilestoneNumber int
+	SkipMilestone   string
+}
+
+// isEmpty reports whether the change does nothing
+func (c *PropagateChange) isEmpty() bool {
+	return !c.changeMilestone && !c.changeType && len(c.AddLabels) == 0 &&
+		len(c.RemoveLabels) == 0 && len(c.AddAssignees) == 0 && len(c.AddProjects) == 0
+}
+
+// parseLabelChanges splits +NAME, NAME and -NAME values into labels to add and remove
+func parseLabelChanges(values []string) ([]string, []string, error) {
+	var add, remove []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		name := strings.TrimSpace(strings.TrimLeft(value, "+-"))
+		if name == "" {
+			return nil, nil, fmt.Errorf("invalid label %q", value)
+		}
+		if strings.HasPrefix(value, "-") {
+			remove = append(remove, name)
+		} else {
+			add = append(add, name)
+		}
+	}
+	return add, remove, nil
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// planPropagation computes the changes for every selected descendant of root
+func planPropagation(root *HierarchyNode, opts PropagateOptions, projects map[string][]ProjectSummary) []*PropagateChange {
+	var changes []*PropagateChange
+
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		if opts.Depth > 0 && depth > opts.Depth {
+			return
+		}
+		if opts.State != "all" && node.State != opts.State {
+			return
+		}
+		for _, label := range opts.WithLabels {
+			if !containsFold(node.Labels, label) {
+				return
+			}
+		}
+
+		change := &PropagateChange{Node: node, OldMilestone: node.Milestone, OldIssueType: node.IssueType}
+
+		if opts.Milestone && node.Milestone != root.Milestone {
+			change.Milestone = root.Milestone
+			change.changeMilestone = true
+		}
+		for _, label := range opts.AddLabels {
+			if !containsFold(node.Labels, label) && !containsFold(change.AddLabels, label) {
+				change.AddLabels = append(change.AddLabels, label)
+			}
+		}
+		for _, label := range opts.RemoveLabels {
+			if containsFold(node.Labels, label) {
+				change.RemoveLabels = append(change.RemoveLabels, label)
+			}
+		}
+		if opts.Assignees {
+			for _, assignee := range root.Assignees {
+				if !containsFold(node.Assignees, assignee) {
+					change.AddAssignees = append(change.AddAssignees, assignee)
+				}
+			}
+		}
+		for _, project := range opts.Projects {
+			member := false
+			for _, existing := range projects[node.ID] {
+				if existing.ID == project.ID {
+					member = true
+					break
+				}
+			}
+			if !member {
+				change.AddProjects = append(change.AddProjects, project)
+			}
+		}
+		if opts.Type && node.IssueType != root.IssueType {
+			change.IssueType = root.IssueType
+			change.IssueTypeID = root.IssueTypeID
+			change.changeType = true
+		}
+
+		if !change.isEmpty() {
+			changes = append(changes, change)
+		}
+	})
+
+	return changes
+}
+
+// resolveMilestones looks up the number of the milestone in the repository of
+// every change, once per repository. Descendants in repositories without the
+// milestone keep their milestone and are marked as skipped.
+func resolveMilestones(changes []*PropagateChange, lookup func(owner, repo, milestone string) (int, error)) error {
+	numbers := make(map[string]int)
+	missing := make(map[string]bool)
+	for _, change := range changes {
+		if !change.changeMilestone || change.Milestone == "" {
+			continue
+		}
+		repository := change.Node.Repository
+		if _, ok := numbers[repository]; !ok && !missing[repository] {
+			owner, repo := splitRepository(repository)
+			number, err := lookup(owner, repo, change.Milestone)
+			switch {
+			case errors.Is(err, errMilestoneNotFound):
+				missing[repository] = true
+			case err != nil:
+				return err
+			default:
+				numbers[repository] = number
+			}
+		}
+		if missing[repository] {
+			change.changeMilestone = false
+			change.SkipMilestone = fmt.Sprintf("no milestone %q in %s", change.Milestone, repository)
+			continue
+		}
+		change.MilestoneNumber = numbers[repository]
+	}
+	return nil
+}
+
+// formatPropagation formats the changes as a diff preview
+func formatPropagation(changes []*PropagateChange, repository string) string {
+	var output strings.Builder
+
+	valueOrNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+
+	for _, change := range changes {
+		node := change.Node
+		ref := fmt.Sprintf("#%d", node.Number)
+		if node.Repository != repository {
+			ref = fmt.Sprintf("%s#%d", node.Repository, node.Number)
+		}
+		output.WriteString(fmt.Sprintf("%s %s\n", ref, node.Title))
+
+		if change.changeMilestone {
+			output.WriteString(fmt.Sprintf("  milestone: %s → %s\n", valueOrNone(change.OldMilestone), valueOrNone(change.Milestone)))
+		}
+		if change.SkipMilestone != "" {
+			output.WriteString(fmt.Sprintf("  milestone: skipped, %s\n", change.SkipMilestone))
+		}
+		if len(change.AddLabels) > 0 || len(change.RemoveLabels) > 0 {
+			var parts []string
+			for _, label := range change.AddLabels {
+				parts = append(parts, "+"+label)
+			}
+			for _, label := range change.RemoveLabels {
+				parts = append(parts, "-"+label)
+			}
+			output.WriteString("  labels: " + strings.Join(parts, " ") + "\n")
+		}
+		if len(change.AddAssignees) > 0 {
+			output.WriteString("  assignees: +@" + strings.Join(change.AddAssignees, " +@") + "\n")
+		}
+		for _, project := range change.AddProjects {
+			output.WriteString(fmt.Sprintf("  project: +%s\n", project.Title))
+		}
+		if change.changeType {
+			output.WriteString(fmt.Sprintf("  type: %s → %s\n", valueOrNone(change.OldIssueType), valueOrNone(change.IssueType)))
+		}
+	}
+
+	return output.String()
+}
+
+// applyPropagateChange performs the mutations of one change
+func applyPropagateChange(client *api.GraphQLClient, change *PropagateChange) error {
+	node := change.Node
+	owner, repo := splitRepository(node.Repository)
+
+	if change.changeMilestone {
+		if err := setIssueMilestone(owner, repo, node.Number, change.MilestoneNumber, node.MilestoneNumber); err != nil {
+			return err
+		}
+	}
+	if len(change.AddLabels) > 0 {
+		if err := addIssueLabels(owner, repo, node.Number, change.AddLabels); err != nil {
+			return err
+		}
+	}
+	for _, label := range change.RemoveLabels {
+		if err := removeIssueLabel(owner, repo, node.Number, label); err != nil {
+			return err
+		}
+	}
+	if len(change.AddAssignees) > 0 {
+		if err := addIssueAssignees(owner, repo, node.Number, change.AddAssignees); err != nil {
+			return err
+		}
+	}
+	for _, project := range change.AddProjects {
+		if _, err := addProjectItem(client, project, node.ID); err != nil {
+			return err
+		}
+	}
+	if change.changeType {
+		if err := setIssueType(client, node.ID, change.IssueTypeID, node.IssueTypeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executePropagation applies the changes with at most concurrency issues in flight
+func executePropagation(cmd *cobra.Command, client *api.GraphQLClient, changes []*PropagateChange, concurrency int) error {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	// A dry run records the changes one at a time, in order
+	if dryRun != nil {
+		concurrency = 1
+	}
+	semaphore := make(chan struct{}, max(concurrency, 1))
+
+	for _, change := range changes {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(change *PropagateChange) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			err := applyPropagateChange(client, change)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				fmt.Fprintf(cmd.ErrOrStderr(), "✗ Failed to update #%d: %v\n", change.Node.Number, err)
+				return
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated #%d %s\n", change.Node.Number, change.Node.Title)
+		}(change)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d of %d issues", failed, len(changes))
+	}
+	return nil
+}
+
+// runPropagate is the main command logic for propagate
+func runPropagate(cmd *cobra.Command, args []string) error {
+	addLabels, removeLabels, err := parseLabelChanges(propagateLabelFlag)
+	if err != nil {
+		return err
+	}
+	if !propagateMilestoneFlag && !propagateLabelsFlag && len(propagateLabelFlag) == 0 &&
+		!propagateAssigneesFlag && !propagateProjectFlag && !propagateTypeFlag {
+		return fmt.Errorf("nothing to propagate (use --milestone, --labels, --label, --assignees, --project or --type)")
+	}
+	switch propagateStateFlag {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid state: %s (expected open, closed or all)", propagateStateFlag)
+	}
+
+	defaultOwner, defaultRepo, err := resolveRepo(propagateRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid parent issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", parentRef.Number, parentRef.Owner, parentRef.Repo)
+	root, err := fetchHierarchy(client, parentRef.Owner, parentRef.Repo, parentRef.Number, propagateDepthFlag)
+	if err != nil {
+		return err
+	}
+
+	if propagateMilestoneFlag && root.Milestone == "" {
+		return fmt.Errorf("parent issue #%d has no milestone", root.Number)
+	}
+	if propagateTypeFlag && root.IssueType == "" {
+		return fmt.Errorf("parent issue #%d has no issue type", root.Number)
+	}
+
+	opts := PropagateOptions{
+		Milestone:    propagateMilestoneFlag,
+		AddLabels:    addLabels,
+		RemoveLabels: removeLabels,
+		Assignees:    propagateAssigneesFlag,
+		Type:         propagateTypeFlag,
+		State:        propagateStateFlag,
+		Depth:        propagateDepthFlag,
+		WithLabels:   propagateWithLabelFlag,
+	}
+	if propagateLabelsFlag {
+		opts.AddLabels = append(append([]string{}, root.Labels...), opts.AddLabels...)
+	}
+
+	var projects map[string][]ProjectSummary
+	if propagateProjectFlag {
+		ids := []string{root.ID}
+		walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+			ids = append(ids, node.ID)
+		})
+		projects, err = getIssueProjects(client, ids)
+		if err != nil {
+			return err
+		}
+		opts.Projects = projects[root.ID]
+	}
+
+	changes := planPropagation(root, opts, projects)
+	if len(changes) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ All descendants of #%d are already up to date\n", root.Number)
+		return nil
+	}
+
+	// Milestones are numbered per repository, so look them up before the preview
+	if err := resolveMilestones(changes, findMilestoneNumber); err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), formatPropagation(changes, parentRef.Owner+"/"+parentRef.Repo))
+
+	// Descendants whose only change was skipped have nothing left to update
+	var updates []*PropagateChange
+	for _, change := range changes {
+		if !change.isEmpty() {
+			updates = append(updates, change)
+		}
+	}
+	if len(updates) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Nothing left to update below #%d\n", root.Number)
+		return nil
+	}
+
+	if !propagateYesFlag && dryRun == nil {
+		if !term.IsTerminal(os.Stdin) {
+			return fmt.Errorf("refusing to propagate without confirmation (use --yes)")
+		}
+		ok, err := confirm(cmd, fmt.Sprintf("Update %d issues?", len(updates)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("propagate cancelled")
+		}
+	}
+
+	return executePropagation(cmd, client, updates, propagateConcurrencyFlag)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelChanges(t *testing.T) {
+	add, remove, err := parseLabelChanges([]string{"+bug", "docs", "-needs-triage", " -wip "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(add, []string{"bug", "docs"}) {
+		t.Errorf("add = %v", add)
+	}
+	if !reflect.DeepEqual(remove, []string{"needs-triage", "wip"}) {
+		t.Errorf("remove = %v", remove)
+	}
+
+	for _, value := range []string{"", "+", "-"} {
+		if _, _, err := parseLabelChanges([]string{value}); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+// propagateTestTree builds a parent with an open child, a closed child and an open grandchild
+func propagateTestTree() *HierarchyNode {
+	grandchild := &HierarchyNode{ID: "G", Repository: "owner/repo", Number: 4, Title: "Grandchild", State: "open",
+		Labels: []string{"backend"}}
+	return &HierarchyNode{
+		ID: "P", Repository: "owner/repo", Number: 1, Title: "Parent", State: "open",
+		Milestone: "v2", Labels: []string{"epic"}, Assignees: []string{"alice"},
+		IssueType: "Feature", IssueTypeID: "IT_feature",
+		SubIssues: []*HierarchyNode{
+			{ID: "A", Repository: "owner/repo", Number: 2, Title: "Open child", State: "open",
+				Milestone: "v1", Labels: []string{"Epic", "needs-triage"}, Assignees: []string{"alice"},
+				IssueType: "Feature", SubIssues: []*HierarchyNode{grandchild}},
+			{ID: "B", Repository: "owner/repo", Number: 3, Title: "Closed child", State: "closed"},
+		},
+	}
+}
+
+func TestPlanPropagation(t *testing.T) {
+	root := propagateTestTree()
+	opts := PropagateOptions{
+		Milestone:    true,
+		AddLabels:    []string{"epic"},
+		RemoveLabels: []string{"needs-triage"},
+		Assignees:    true,
+		Projects:     []ProjectSummary{{ID: "PRJ", Title: "Roadmap"}},
+		Type:         true,
+		State:        "open",
+	}
+	projects := map[string][]ProjectSummary{"A": {{ID: "PRJ", Title: "Roadmap"}}}
+
+	changes := planPropagation(root, opts, projects)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+
+	a := changes[0]
+	if a.Node.Number != 2 || !a.changeMilestone || a.Milestone != "v2" {
+		t.Errorf("child #2: unexpected milestone change %+v", a)
+	}
+	if len(a.AddLabels) != 0 || !reflect.DeepEqual(a.RemoveLabels, []string{"needs-triage"}) {
+		t.Errorf("child #2: labels +%v -%v", a.AddLabels, a.RemoveLabels)
+	}
+	if len(a.AddAssignees) != 0 || len(a.AddProjects) != 0 || a.changeType {
+		t.Errorf("child #2: unexpected changes %+v", a)
+	}
+
+	g := changes[1]
+	if g.Node.Number != 4 || !reflect.DeepEqual(g.AddLabels, []string{"epic"}) ||
+		!reflect.DeepEqual(g.AddAssignees, []string{"alice"}) || len(g.AddProjects) != 1 ||
+		!g.changeType || g.IssueTypeID != "IT_feature" {
+		t.Errorf("grandchild: unexpected change %+v", g)
+	}
+}
+
+func TestPlanPropagationFilters(t *testing.T) {
+	root := propagateTestTree()
+
+	changes := planPropagation(root, PropagateOptions{Milestone: true, State: "all", Depth: 1}, nil)
+	if len(changes) != 2 || changes[0].Node.Number != 2 || changes[1].Node.Number != 3 {
+		t.Errorf("depth 1, all states: got %d changes", len(changes))
+	}
+
+	changes = planPropagation(root, PropagateOptions{Milestone: true, State: "open", WithLabels: []string{"BACKEND"}}, nil)
+	if len(changes) != 1 || changes[0].Node.Number != 4 {
+		t.Errorf("with-label filter: got %d changes", len(changes))
+	}
+}
+
+func TestFormatPropagation(t *testing.T) {
+	changes := []*PropagateChange{
+		{
+			Node:            &HierarchyNode{Repository: "owner/repo", Number: 2, Title: "Child"},
+			Milestone:       "v2",
+			OldMilestone:    "v1",
+			changeMilestone: true,
+			AddLabels:       []string{"epic"},
+			RemoveLabels:    []string{"wip"},
+			AddAssignees:    []string{"alice", "bob"},
+		},
+		{
+			Node:         &HierarchyNode{Repository: "owner/other", Number: 5, Title: "Elsewhere"},
+			AddProjects:  []ProjectSummary{{ID: "PRJ", Title: "Roadmap"}},
+			IssueType:    "Bug",
+			changeType:   true,
+			OldIssueType: "",
+		},
+	}
+
+	want := "#2 Child\n" +
+		"  milestone: v1 → v2\n" +
+		"  labels: +epic -wip\n" +
+		"  assignees: +@alice +@bob\n" +
+		"owner/other#5 Elsewhere\n" +
+		"  project: +Roadmap\n" +
+		"  type: (none) → Bug\n"
+
+	if got := formatPropagation(changes, "owner/repo"); got != want {
+		t.Errorf("formatPropagation() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestResolveMilestones(t *testing.T) {
+	changes := []*PropagateChange{
+		{Node: &HierarchyNode{Repository: "owner/repo", Number: 2}, Milestone: "v2", changeMilestone: true},
+		{Node: &HierarchyNode{Repository: "owner/other", Number: 5}, Milestone: "v2", changeMilestone: true},
+		{Node: &HierarchyNode{Repository: "owner/other", Number: 6}, Milestone: "v2", changeMilestone: true, AddLabels: []string{"epic"}},
+	}
+	lookups := 0
+	lookup := func(owner, repo, milestone string) (int, error) {
+		lookups++
+		if repo == "other" {
+			return 0, fmt.Errorf("milestone %q %w in %s/%s", milestone, errMilestoneNotFound, owner, repo)
+		}
+		return 7, nil
+	}
+
+	if err := resolveMilestones(changes, lookup); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookups != 2 {
+		t.Errorf("looked up milestones %d times, want once per repository", lookups)
+	}
+	if !changes[0].changeMilestone || changes[0].MilestoneNumber != 7 {
+		t.Errorf("unexpected change %+v", changes[0])
+	}
+	if changes[1].changeMilestone || !changes[1].isEmpty() || changes[2].isEmpty() {
+		t.Errorf("expected the milestone to be skipped in owner/other, got %+v and %+v", changes[1], changes[2])
+	}
+	want := "owner/other#5 \n  milestone: skipped, no milestone \"v2\" in owner/other\n"
+	if got := formatPropagation(changes[1:2], "owner/repo"); got != want {
+		t.Errorf("formatPropagation() = %q, want %q", got, want)
+	}
+
+	failing := func(owner, repo, milestone string) (int, error) { return 0, fmt.Errorf("HTTP 502") }
+	changes = []*PropagateChange{{Node: &HierarchyNode{Repository: "owner/repo"}, Milestone: "v2", changeMilestone: true}}
+	if err := resolveMilestones(changes, failing); err == nil {
+		t.Error("expected lookup errors other than a missing milestone to be returned")
+	}
+}
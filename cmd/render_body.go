@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	managedSectionStart = "<!-- sub-issues:start -->"
+	managedSectionEnd   = "<!-- sub-issues:end -->"
+)
+
+var (
+	renderBodyRepoFlag  string
+	renderBodyLimitFlag int
+)
+
+var renderBodyCmd = &cobra.Command{
+	Use:     "render-body <parent-issue>",
+	Aliases: []string{"sync-body"},
+	Short:   "Render sub-issues as a checklist in the parent issue body",
+	Long: `Write a checklist of all sub-issues into a managed section of the parent issue body.
+
+The section is delimited by <!-- sub-issues:start --> and <!-- sub-issues:end -->
+markers. Running the command again replaces the section in place, and the body is
+left untouched when nothing changed, so it is safe to run from a scheduled workflow.
+
+Examples:
+  # Render the checklist into issue #123
+  gh sub-issues render-body 123
+
+  # Same, using the alias
+  gh sub-issues sync-body https://github.com/owner/repo/issues/123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRenderBody,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(renderBodyCmd)
+
+	// Add flags
+	renderBodyCmd.Flags().StringVarP(&renderBodyRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	renderBodyCmd.Flags().IntVarP(&renderBodyLimitFlag, "limit", "L", 100, "Maximum number of sub-issues to render")
+}
+
+// issueRefString formats an issue reference relative to the given repository
+func issueRefString(url string, number int, owner, repo string) string {
+	ref, err := parseIssueURL(url)
+	if err != nil || (ref.Owner == owner && ref.Repo == repo) {
+		return fmt.Sprintf("#%d", number)
+	}
+	return fmt.Sprintf("%s/%s#%d", ref.Owner, ref.Repo, ref.Number)
+}
+
+// renderSubIssueSection renders the managed checklist section for a parent issue
+func renderSubIssueSection(result *ListResult, owner, repo string) string {
+	var output strings.Builder
+
+	closedCount := result.Total - result.OpenCount
+	output.WriteString(managedSectionStart + "\n")
+	output.WriteString(fmt.Sprintf("### Sub-issues (%d/%d completed)\n\n", closedCount, result.Total))
+
+	for _, issue := range result.SubIssues {
+		check := " "
+		if issue.State == "closed" {
+			check = "x"
+		}
+		output.WriteString(fmt.Sprintf("- [%s] %s %s\n",
+			check, issueRefString(issue.URL, issue.Number, owner, repo), issue.Title))
+	}
+
+	output.WriteString(managedSectionEnd)
+	return output.String()
+}
+
+// upsertManagedSection replaces the managed section in a body, or appends it if missing
+func upsertManagedSection(body, section string) string {
+	start := strings.Index(body, managedSectionStart)
+	if start >= 0 {
+		if end := strings.Index(body[start:], managedSectionEnd); end >= 0 {
+			end += start + len(managedSectionEnd)
+			return body[:start] + section + body[end:]
+		}
+	}
+
+	trimmed := strings.TrimRight(body, "\r\n")
+	if trimmed == "" {
+		return section + "\n"
+	}
+	return trimmed + "\n\n" + section + "\n"
+}
+
+// removeManagedSection removes the managed section from a body
+func removeManagedSection(body string) string {
+	start := strings.Index(body, managedSectionStart)
+	if start < 0 {
+		return body
+	}
+	end := strings.Index(body[start:], managedSectionEnd)
+	if end < 0 {
+		return body
+	}
+	end += start + len(managedSectionEnd)
+
+	before := strings.TrimRight(body[:start], "\r\n")
+	after := strings.TrimLeft(body[end:], "\r\n")
+	if before == "" || after == "" {
+		return before + after
+	}
+	return before + "\n\n" + after
+}
+
+// runRenderBody is the main command logic
+func runRenderBody(cmd *cobra.Command, args []string) error {
+	defaultOwner, defaultRepo, err := resolveRepo(renderBodyRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid parent issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	parent, err := getIssueDetails(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return err
+	}
+
+	result, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, renderBodyLimitFlag, "all")
+	if err != nil {
+		return err
+	}
+
+	section := renderSubIssueSection(result, parentRef.Owner, parentRef.Repo)
+	body := upsertManagedSection(parent.Body, section)
+
+	if body == parent.Body {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Body of #%d is already up to date\n", parent.Number)
+		return nil
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Updating body of #%d...\n", parent.Number)
+	if err := updateIssueBody(client, parent.ID, parent.Body, body); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Rendered %d sub-issues into the body of #%d\n", result.Total, parent.Number)
+	return nil
+}
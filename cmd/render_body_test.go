@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestRenderSubIssueSection(t *testing.T) {
+	result := &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Epic", State: "open"},
+		SubIssues: []SubIssue{
+			{
+				Number: 2,
+				Title:  "Same repo",
+				State:  "closed",
+				URL:    "https://github.com/owner/repo/issues/2",
+			},
+			{
+				Number: 7,
+				Title:  "Other repo",
+				State:  "open",
+				URL:    "https://github.com/other/project/issues/7",
+			},
+		},
+		Total:     2,
+		OpenCount: 1,
+	}
+
+	expected := "<!-- sub-issues:start -->\n" +
+		"### Sub-issues (1/2 completed)\n\n" +
+		"- [x] #2 Same repo\n" +
+		"- [ ] other/project#7 Other repo\n" +
+		"<!-- sub-issues:end -->"
+
+	output := renderSubIssueSection(result, "owner", "repo")
+	if output != expected {
+		t.Errorf("renderSubIssueSection() output mismatch\nGot:\n%s\nExpected:\n%s", output, expected)
+	}
+}
+
+func TestUpsertManagedSection(t *testing.T) {
+	section := "<!-- sub-issues:start -->\nnew\n<!-- sub-issues:end -->"
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "empty body",
+			body:     "",
+			expected: section + "\n",
+		},
+		{
+			name:     "append to body",
+			body:     "Description\n",
+			expected: "Description\n\n" + section + "\n",
+		},
+		{
+			name:     "replace existing section",
+			body:     "Intro\n<!-- sub-issues:start -->\nold\n<!-- sub-issues:end -->\nOutro",
+			expected: "Intro\n" + section + "\nOutro",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := upsertManagedSection(tt.body, section)
+			if output != tt.expected {
+				t.Errorf("upsertManagedSection() = %q, want %q", output, tt.expected)
+			}
+
+			// Rendering again must not change the body
+			if again := upsertManagedSection(output, section); again != output {
+				t.Errorf("upsertManagedSection() is not idempotent: %q", again)
+			}
+		})
+	}
+}
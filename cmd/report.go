@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportRepoFlag   string
+	reportOutputFlag string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report <issue>",
+	Short: "Render an issue hierarchy as a static HTML report",
+	Long: `Render an issue and all of its sub-issues as a self-contained HTML page.
+
+The page has no external assets and shows a collapsible tree with progress
+bars, a breakdown by assignee and label, and links back to GitHub. It is
+built from the same data as export.
+
+Examples:
+  # Write the report to a file
+  gh sub-issues report 123 -o report.html
+
+  # Write to stdout
+  gh sub-issues report https://github.com/owner/repo/issues/123 > report.html`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReport,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(reportCmd)
+
+	// Add flags
+	reportCmd.Flags().StringVarP(&reportRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	reportCmd.Flags().StringVarP(&reportOutputFlag, "output", "o", "", "Write to file instead of stdout")
+}
+
+// reportNode is a hierarchy node with the progress of its descendants
+type reportNode struct {
+	*HierarchyNode
+	Closed   int
+	Total    int
+	Children []*reportNode
+}
+
+// Percent returns the share of closed descendants
+func (n *reportNode) Percent() int {
+	return percentOf(n.Closed, n.Total)
+}
+
+// reportCount is the open and closed issue count of an assignee or label
+type reportCount struct {
+	Name   string
+	Open   int
+	Closed int
+}
+
+// Total returns the number of issues counted
+func (c reportCount) Total() int {
+	return c.Open + c.Closed
+}
+
+// Percent returns the share of closed issues
+func (c reportCount) Percent() int {
+	return percentOf(c.Closed, c.Total())
+}
+
+// ReportData is the data rendered into the HTML report
+type ReportData struct {
+	Root        *reportNode
+	Assignees   []reportCount
+	Labels      []reportCount
+	GeneratedAt time.Time
+}
+
+// percentOf returns part as a whole-number percentage of total
+func percentOf(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return part * 100 / total
+}
+
+// buildReportNode computes descendant progress for a hierarchy
+func buildReportNode(node *HierarchyNode) *reportNode {
+	rn := &reportNode{HierarchyNode: node}
+	for _, child := range node.SubIssues {
+		rc := buildReportNode(child)
+		rn.Children = append(rn.Children, rc)
+		rn.Total += rc.Total + 1
+		rn.Closed += rc.Closed
+		if child.State == "closed" {
+			rn.Closed++
+		}
+	}
+	return rn
+}
+
+// buildReportData aggregates a hierarchy into report data
+func buildReportData(root *HierarchyNode, generatedAt time.Time) *ReportData {
+	assignees := make(map[string]*reportCount)
+	labels := make(map[string]*reportCount)
+
+	count := func(counts map[string]*reportCount, name, state string) {
+		c, ok := counts[name]
+		if !ok {
+			c = &reportCount{Name: name}
+			counts[name] = c
+		}
+		if state == "closed" {
+			c.Closed++
+		} else {
+			c.Open++
+		}
+	}
+
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		if len(node.Assignees) == 0 {
+			count(assignees, "", node.State)
+		}
+		for _, assignee := range node.Assignees {
+			count(assignees, assignee, node.State)
+		}
+		for _, label := range node.Labels {
+			count(labels, label, node.State)
+		}
+	})
+
+	return &ReportData{
+		Root:        buildReportNode(root),
+		Assignees:   sortedReportCounts(assignees),
+		Labels:      sortedReportCounts(labels),
+		GeneratedAt: generatedAt,
+	}
+}
+
+// sortedReportCounts orders counts by total issues, then by name
+func sortedReportCounts(counts map[string]*reportCount) []reportCount {
+	var result []reportCount
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total() != result[j].Total() {
+			return result[i].Total() > result[j].Total()
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// reportTemplate is the self-contained HTML report
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>#{{.Root.Number}} {{.Root.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 2rem auto; max-width: 960px; padding: 0 1rem; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+h1 { font-size: 1.6rem; margin-bottom: 0.25rem; }
+h2 { font-size: 1.2rem; margin-top: 2rem; border-bottom: 1px solid #d0d7de; padding-bottom: 0.3rem; }
+.meta { color: #656d76; font-size: 0.85rem; }
+.bar { display: inline-block; width: 120px; height: 8px; background: #eaeef2; border-radius: 4px; overflow: hidden; vertical-align: middle; }
+.bar > span { display: block; height: 100%; background: #8250df; }
+.bar.large { width: 100%; height: 12px; }
+.state { display: inline-block; font-size: 0.75rem; padding: 0 0.5rem; border-radius: 1rem; color: #fff; }
+.state.open { background: #1a7f37; }
+.state.closed { background: #8250df; }
+.tree, .tree ul { list-style: none; padding-left: 1.25rem; margin: 0; }
+.tree { padding-left: 0; }
+.tree li { margin: 0.2rem 0; }
+.tree summary { cursor: pointer; }
+.tree .leaf { padding-left: 1rem; }
+.people { color: #656d76; font-size: 0.85rem; }
+.label { display: inline-block; font-size: 0.75rem; padding: 0 0.4rem; border: 1px solid #d0d7de; border-radius: 1rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.35rem 0.5rem; border-bottom: 1px solid #d0d7de; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+</style>
+</head>
+<body>
+<h1><a href="{{.Root.URL}}">#{{.Root.Number}}</a> {{.Root.Title}}</h1>
+<p class="meta">{{.Root.Repository}} · <span class="state {{.Root.State}}">{{.Root.State}}</span> · generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</p>
+<p>{{.Root.Closed}} of {{.Root.Total}} sub-issues completed ({{.Root.Percent}}%)</p>
+<div class="bar large"><span style="width: {{.Root.Percent}}%"></span></div>
+
+<h2>Hierarchy</h2>
+<ul class="tree">
+{{- range .Root.Children}}{{template "node" .}}{{end}}
+</ul>
+
+<h2>By assignee</h2>
+{{template "counts" .Assignees}}
+
+<h2>By label</h2>
+{{template "counts" .Labels}}
+</body>
+</html>
+{{define "issue"}}<span class="state {{.State}}">{{.State}}</span> <a href="{{.URL}}">{{.Repository}}#{{.Number}}</a> {{.Title}}
+{{- range .Labels}} <span class="label">{{.}}</span>{{end}}
+{{- if .Assignees}} <span class="people">{{range $i, $a := .Assignees}}{{if $i}}, {{end}}@{{$a}}{{end}}</span>{{end}}
+{{- end}}
+{{define "node"}}
+<li>
+{{- if .Children}}<details open><summary>{{template "issue" .}} <span class="bar"><span style="width: {{.Percent}}%"></span></span> {{.Closed}}/{{.Total}}</summary>
+<ul>
+{{- range .Children}}{{template "node" .}}{{end}}
+</ul>
+</details>
+{{- else}}<div class="leaf">{{template "issue" .}}</div>{{end}}
+</li>
+{{- end}}
+{{define "counts"}}{{if .}}
+<table>
+<tr><th>Name</th><th>Open</th><th>Closed</th><th>Progress</th></tr>
+{{- range .}}
+<tr><td>{{if .Name}}{{.Name}}{{else}}<em>none</em>{{end}}</td><td class="num">{{.Open}}</td><td class="num">{{.Closed}}</td><td><span class="bar"><span style="width: {{.Percent}}%"></span></span> {{.Percent}}%</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="meta">Nothing to show.</p>
+{{- end}}{{end}}
+`))
+
+// renderReport renders a hierarchy as an HTML report
+func renderReport(root *HierarchyNode, generatedAt time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, buildReportData(root, generatedAt)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// runReport is the main command logic for report
+func runReport(cmd *cobra.Command, args []string) error {
+	defaultOwner, defaultRepo, err := resolveRepo(reportRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, 0)
+	if err != nil {
+		return err
+	}
+
+	data, err := renderReport(root, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+
+	if reportOutputFlag == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(reportOutputFlag, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", reportOutputFlag, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Wrote report for #%d to %s\n", root.Number, reportOutputFlag)
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func reportTestHierarchy() *HierarchyNode {
+	return &HierarchyNode{
+		Repository: "owner/repo",
+		Number:     1,
+		Title:      "Epic",
+		State:      "open",
+		URL:        "https://github.com/owner/repo/issues/1",
+		SubIssues: []*HierarchyNode{
+			{
+				Repository: "owner/repo",
+				Number:     2,
+				Title:      "Backend <api>",
+				State:      "open",
+				URL:        "https://github.com/owner/repo/issues/2",
+				Labels:     []string{"backend"},
+				Assignees:  []string{"alice"},
+				SubIssues: []*HierarchyNode{
+					{Repository: "owner/repo", Number: 3, Title: "Schema", State: "closed", Labels: []string{"backend"}, Assignees: []string{"alice"}},
+					{Repository: "owner/repo", Number: 4, Title: "Handlers", State: "open", Assignees: []string{"bob"}},
+				},
+			},
+			{Repository: "owner/repo", Number: 5, Title: "Docs", State: "closed"},
+		},
+	}
+}
+
+func TestBuildReportData(t *testing.T) {
+	data := buildReportData(reportTestHierarchy(), time.Time{})
+
+	if data.Root.Total != 4 || data.Root.Closed != 2 || data.Root.Percent() != 50 {
+		t.Errorf("root progress = %d/%d (%d%%), expected 2/4 (50%%)", data.Root.Closed, data.Root.Total, data.Root.Percent())
+	}
+	if backend := data.Root.Children[0]; backend.Total != 2 || backend.Closed != 1 {
+		t.Errorf("#2 progress = %d/%d, expected 1/2", backend.Closed, backend.Total)
+	}
+
+	expectedAssignees := []reportCount{
+		{Name: "alice", Open: 1, Closed: 1},
+		{Name: "", Open: 0, Closed: 1},
+		{Name: "bob", Open: 1, Closed: 0},
+	}
+	if len(data.Assignees) != len(expectedAssignees) {
+		t.Fatalf("assignees = %+v, expected %+v", data.Assignees, expectedAssignees)
+	}
+	for i, want := range expectedAssignees {
+		if data.Assignees[i] != want {
+			t.Errorf("assignees[%d] = %+v, expected %+v", i, data.Assignees[i], want)
+		}
+	}
+
+	if len(data.Labels) != 1 || data.Labels[0] != (reportCount{Name: "backend", Open: 1, Closed: 1}) {
+		t.Errorf("labels = %+v, expected backend 1 open, 1 closed", data.Labels)
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	output, err := renderReport(reportTestHierarchy(), time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("renderReport() error: %v", err)
+	}
+	html := string(output)
+
+	expected := []string{
+		"<!DOCTYPE html>",
+		`<a href="https://github.com/owner/repo/issues/2">owner/repo#2</a> Backend &lt;api&gt;`,
+		"<details open>",
+		"2 of 4 sub-issues completed (50%)",
+		`<span style="width: 50%">`,
+		"generated 2026-01-02 03:04 UTC",
+		"<em>none</em>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(html, want) {
+			t.Errorf("renderReport() missing %q", want)
+		}
+	}
+
+	for _, external := range []string{"<script src", "<link ", "@import"} {
+		if strings.Contains(html, external) {
+			t.Errorf("renderReport() references external asset %q", external)
+		}
+	}
+}
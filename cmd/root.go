@@ -17,14 +17,37 @@ var rootCmd = &cobra.Command{
 This extension allows you to:
 - Link existing issues as sub-issues to parent issues
 - Create new sub-issues directly linked to parent issues
-- List all sub-issues for a given parent issue`,
+- List all sub-issues for a given parent issue
+- Convert task lists in an issue body into sub-issues
+- Render sub-issues as a checklist in the parent issue body
+- Create and link issue hierarchies from YAML plans
+- Export hierarchies to files and import them into other repositories
+- Clone hierarchies as templates
+- Browse and edit hierarchies in an interactive terminal UI
+- Render hierarchies as static HTML reports
+- Track blocked-by dependencies between issues
+- Find ready-to-start work and the critical path of an epic
+- Propagate milestones, labels, assignees, projects and issue types to sub-issues
+- Enforce issue type rules between parents and sub-issues
+- Lint hierarchies for closed parents, finished work and other problems
+- Find and attach issues that have no parent
+- Preview every change with a global --dry-run
+- Undo changes from a local journal of operations
+- Snapshot hierarchies and diff them over time
+- Chart burndown and burnup of sub-issues over time
+- Show open work per assignee across hierarchies
+- Keep sub-issue lists up to date with --watch`,
 	Version: Version,
 }
 
 func Execute() int {
 	// Add subcommands here (will be added in next tasks)
 	
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if planErr := finishDryRun(); err == nil {
+		err = planErr
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotRepoFlag   string
+	snapshotOutputFlag string
+	diffJSONFlag       bool
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot <issue>",
+	Short: "Save the current state of an issue hierarchy to a file",
+	Long: `Save an issue and all of its sub-issues, recursively, to a file that can
+be compared with a later state using diff.
+
+Snapshots use the same format as export. Without --output, the snapshot is
+written to REPO-NUMBER-TIMESTAMP.json in the current directory.
+
+Examples:
+  # Take a snapshot at the start of a sprint
+  gh sub-issues snapshot 123
+
+  # Write the snapshot to a given file
+  gh sub-issues snapshot 123 -o sprint-12.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshot,
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <snapshot-a> [<snapshot-b>|live]",
+	Short: "Show what changed in a hierarchy between two snapshots",
+	Long: `Compare two snapshots of a hierarchy, or a snapshot with the live hierarchy.
+
+Reports sub-issues that were added, removed or moved to another parent, and
+issues whose state, assignees or title changed. Without a second snapshot,
+the snapshot is compared with the live hierarchy.
+
+Examples:
+  # What changed since the snapshot
+  gh sub-issues diff sprint-12.json
+
+  # Compare two snapshots
+  gh sub-issues diff sprint-11.json sprint-12.json
+
+  # Output in JSON format
+  gh sub-issues diff sprint-12.json live --json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDiff,
+}
+
+func init() {
+	// Add commands to root
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(diffCmd)
+
+	// Add flags
+	snapshotCmd.Flags().StringVarP(&snapshotRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	snapshotCmd.Flags().StringVarP(&snapshotOutputFlag, "output", "o", "", "Write to this file (default: REPO-NUMBER-TIMESTAMP.json)")
+	diffCmd.Flags().BoolVar(&diffJSONFlag, "json", false, "Output in JSON format")
+}
+
+// Kinds of hierarchy changes
+const (
+	changeAdded     = "added"
+	changeRemoved   = "removed"
+	changeMoved     = "moved"
+	changeState     = "state"
+	changeAssignees = "assignees"
+	changeTitle     = "title"
+)
+
+// changeSummaries labels the kinds of changes in the summary, in order
+var changeSummaries = []struct {
+	Kind  string
+	Label string
+}{
+	{changeAdded, "added"},
+	{changeRemoved, "removed"},
+	{changeMoved, "moved"},
+	{changeState, "state changes"},
+	{changeAssignees, "reassigned"},
+	{changeTitle, "retitled"},
+}
+
+// HierarchyChange is one difference between two states of a hierarchy
+type HierarchyChange struct {
+	Kind  string    `json:"kind"`
+	Issue IssueLink `json:"issue"`
+	From  string    `json:"from,omitempty"`
+	To    string    `json:"to,omitempty"`
+}
+
+// HierarchyDiff is the result of comparing two states of a hierarchy
+type HierarchyDiff struct {
+	Root    IssueLink         `json:"root"`
+	From    time.Time         `json:"from"`
+	To      *time.Time        `json:"to"`
+	Changes []HierarchyChange `json:"changes"`
+}
+
+// snapshotFileName returns the default file name of a snapshot
+func snapshotFileName(repo string, number int, now time.Time) string {
+	return fmt.Sprintf("%s-%d-%s.json", repo, number, now.Format("20060102-150405"))
+}
+
+// nodeLink returns the issue link of a hierarchy node
+func nodeLink(node *HierarchyNode) IssueLink {
+	return IssueLink{
+		ID:         node.ID,
+		Repository: node.Repository,
+		Number:     node.Number,
+		Title:      node.Title,
+		State:      node.State,
+		URL:        node.URL,
+		Assignees:  node.Assignees,
+	}
+}
+
+// indexHierarchy maps each issue of a hierarchy by its reference to its node and parent
+func indexHierarchy(root *HierarchyNode) (map[string]*HierarchyNode, map[string]*HierarchyNode) {
+	nodes := map[string]*HierarchyNode{linkRef(nodeLink(root), ""): root}
+	parents := make(map[string]*HierarchyNode)
+	walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+		key := linkRef(nodeLink(node), "")
+		nodes[key] = node
+		parents[key] = parent
+	})
+	return nodes, parents
+}
+
+// diffHierarchies compares two states of a hierarchy. Issues are matched by
+// repository and number; changes follow the order of the newer hierarchy,
+// followed by removed issues.
+func diffHierarchies(before, after *HierarchyNode) []HierarchyChange {
+	beforeNodes, beforeParents := indexHierarchy(before)
+	afterNodes, afterParents := indexHierarchy(after)
+	repository := after.Repository
+	parentRef := func(parent *HierarchyNode) string {
+		if parent == nil {
+			return ""
+		}
+		return linkRef(nodeLink(parent), repository)
+	}
+
+	changes := []HierarchyChange{}
+	compare := func(node, parent *HierarchyNode, depth int) {
+		link := nodeLink(node)
+		key := linkRef(link, "")
+		old, ok := beforeNodes[key]
+		if !ok {
+			changes = append(changes, HierarchyChange{Kind: changeAdded, Issue: link, To: parentRef(parent)})
+			return
+		}
+
+		if from, to := parentRef(beforeParents[key]), parentRef(afterParents[key]); from != to {
+			changes = append(changes, HierarchyChange{Kind: changeMoved, Issue: link, From: from, To: to})
+		}
+		if old.State != node.State {
+			changes = append(changes, HierarchyChange{Kind: changeState, Issue: link, From: old.State, To: node.State})
+		}
+		if from, to := joinSorted(old.Assignees), joinSorted(node.Assignees); from != to {
+			changes = append(changes, HierarchyChange{Kind: changeAssignees, Issue: link, From: from, To: to})
+		}
+		if old.Title != node.Title {
+			changes = append(changes, HierarchyChange{Kind: changeTitle, Issue: link, From: old.Title, To: node.Title})
+		}
+	}
+	compare(after, nil, 0)
+	walkHierarchy(after, compare)
+
+	walkHierarchy(before, func(node, parent *HierarchyNode, depth int) {
+		if _, ok := afterNodes[linkRef(nodeLink(node), "")]; !ok {
+			changes = append(changes, HierarchyChange{Kind: changeRemoved, Issue: nodeLink(node), From: parentRef(parent)})
+		}
+	})
+	return changes
+}
+
+// describeChange describes a hierarchy change on one line
+func describeChange(change HierarchyChange, repository string) string {
+	issue := fmt.Sprintf("%s %s", linkRef(change.Issue, repository), change.Issue.Title)
+	orNone := func(value string) string {
+		if value == "" {
+			return "(none)"
+		}
+		return value
+	}
+
+	switch change.Kind {
+	case changeAdded:
+		return fmt.Sprintf("+ %s (added to %s)", issue, change.To)
+	case changeRemoved:
+		return fmt.Sprintf("- %s (removed from %s)", issue, change.From)
+	case changeMoved:
+		return fmt.Sprintf("> %s: moved from %s to %s", issue, change.From, change.To)
+	case changeState:
+		return fmt.Sprintf("~ %s: %s → %s", issue, change.From, change.To)
+	case changeAssignees:
+		return fmt.Sprintf("~ %s: assignees %s → %s", issue, orNone(change.From), orNone(change.To))
+	case changeTitle:
+		return fmt.Sprintf("~ %s: retitled from %q", issue, change.From)
+	}
+	return fmt.Sprintf("? %s: %s", issue, change.Kind)
+}
+
+// formatDiffText describes the changes with a summary line
+func formatDiffText(diff *HierarchyDiff) string {
+	var output strings.Builder
+	repository := diff.Root.Repository
+	to := "now"
+	if diff.To != nil {
+		to = diff.To.Local().Format("2006-01-02 15:04")
+	}
+	output.WriteString(fmt.Sprintf("Changes to %s between %s and %s:\n",
+		linkRef(diff.Root, ""), diff.From.Local().Format("2006-01-02 15:04"), to))
+
+	if len(diff.Changes) == 0 {
+		output.WriteString("✓ No changes\n")
+		return output.String()
+	}
+
+	counts := make(map[string]int)
+	for _, change := range diff.Changes {
+		output.WriteString("  " + describeChange(change, repository) + "\n")
+		counts[change.Kind]++
+	}
+
+	var summary []string
+	for _, kind := range changeSummaries {
+		if counts[kind.Kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind.Kind], kind.Label))
+		}
+	}
+	output.WriteString("\n" + strings.Join(summary, ", ") + "\n")
+	return output.String()
+}
+
+// runSnapshot is the main command logic for snapshot
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	defaultOwner, defaultRepo, err := resolveRepo(snapshotRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	path := snapshotOutputFlag
+	if path == "" {
+		path = snapshotFileName(ref.Repo, ref.Number, time.Now())
+	}
+	format, err := exportFormat("", path)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, 0)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalHierarchy(&HierarchyExport{
+		Version:    1,
+		ExportedAt: time.Now().UTC(),
+		Root:       root,
+	}, format)
+	if err != nil {
+		return fmt.Errorf("failed to encode hierarchy: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Saved snapshot of #%d to %s\n", root.Number, path)
+	return nil
+}
+
+// checkSameRoot makes sure two snapshots are of the same issue, as diffing
+// different hierarchies would report every issue as added or removed
+func checkSameRoot(before, after *HierarchyNode) error {
+	if before.Repository != after.Repository || before.Number != after.Number {
+		return fmt.Errorf("snapshots are of different issues: %s#%d and %s#%d",
+			before.Repository, before.Number, after.Repository, after.Number)
+	}
+	return nil
+}
+
+// runDiff is the main command logic for diff
+func runDiff(cmd *cobra.Command, args []string) error {
+	before, err := readHierarchyFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	diff := &HierarchyDiff{Root: nodeLink(before.Root), From: before.ExportedAt}
+	var after *HierarchyNode
+	if len(args) == 2 && args[1] != "live" {
+		snapshot, err := readHierarchyFile(args[1])
+		if err != nil {
+			return err
+		}
+		if err := checkSameRoot(before.Root, snapshot.Root); err != nil {
+			return err
+		}
+		after = snapshot.Root
+		diff.To = &snapshot.ExportedAt
+	} else {
+		client, err := api.NewGraphQLClient(api.ClientOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+
+		owner, repo := splitRepository(before.Root.Repository)
+		fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", before.Root.Number, owner, repo)
+		after, err = fetchHierarchy(client, owner, repo, before.Root.Number, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	diff.Changes = diffHierarchies(before.Root, after)
+
+	if diffJSONFlag {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), formatDiffText(diff))
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// snapshotTestTrees returns an older and a newer state of one hierarchy
+func snapshotTestTrees() (*HierarchyNode, *HierarchyNode) {
+	before := &HierarchyNode{Repository: "owner/repo", Number: 1, Title: "Epic", State: "open",
+		SubIssues: []*HierarchyNode{
+			{Repository: "owner/repo", Number: 2, Title: "Design", State: "open", Assignees: []string{"alice"},
+				SubIssues: []*HierarchyNode{
+					{Repository: "owner/repo", Number: 4, Title: "Mockups", State: "open"},
+				}},
+			{Repository: "owner/repo", Number: 3, Title: "Build", State: "open"},
+			{Repository: "owner/other", Number: 5, Title: "Dropped", State: "open"},
+		}}
+	after := &HierarchyNode{Repository: "owner/repo", Number: 1, Title: "Epic", State: "closed",
+		SubIssues: []*HierarchyNode{
+			{Repository: "owner/repo", Number: 2, Title: "Design", State: "closed", Assignees: []string{"bob"}},
+			{Repository: "owner/repo", Number: 3, Title: "Build the thing", State: "open",
+				SubIssues: []*HierarchyNode{
+					{Repository: "owner/repo", Number: 4, Title: "Mockups", State: "open"},
+				}},
+			{Repository: "owner/repo", Number: 6, Title: "Docs", State: "open"},
+		}}
+	return before, after
+}
+
+func TestDiffHierarchies(t *testing.T) {
+	before, after := snapshotTestTrees()
+
+	var got []string
+	for _, change := range diffHierarchies(before, after) {
+		got = append(got, describeChange(change, "owner/repo"))
+	}
+	want := []string{
+		"~ #1 Epic: open → closed",
+		"~ #2 Design: open → closed",
+		"~ #2 Design: assignees alice → bob",
+		"~ #3 Build the thing: retitled from \"Build\"",
+		"> #4 Mockups: moved from #2 to #3",
+		"+ #6 Docs (added to #1)",
+		"- owner/other#5 Dropped (removed from #1)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if changes := diffHierarchies(before, before); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestFormatDiffText(t *testing.T) {
+	before, after := snapshotTestTrees()
+	from := time.Date(2026, 10, 11, 9, 0, 0, 0, time.Local)
+	diff := &HierarchyDiff{Root: nodeLink(before), From: from, Changes: diffHierarchies(before, after)}
+
+	got := formatDiffText(diff)
+	if !strings.HasPrefix(got, "Changes to owner/repo#1 between 2026-10-11 09:00 and now:\n") {
+		t.Errorf("unexpected header in:\n%s", got)
+	}
+	if !strings.HasSuffix(got, "\n1 added, 1 removed, 1 moved, 2 state changes, 1 reassigned, 1 retitled\n") {
+		t.Errorf("unexpected summary in:\n%s", got)
+	}
+
+	to := from.Add(7 * 24 * time.Hour)
+	diff = &HierarchyDiff{Root: nodeLink(before), From: from, To: &to, Changes: diffHierarchies(before, before)}
+	want := "Changes to owner/repo#1 between 2026-10-11 09:00 and 2026-10-18 09:00:\n✓ No changes\n"
+	if got := formatDiffText(diff); got != want {
+		t.Errorf("formatDiffText() = %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotFileName(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 4, 5, 0, time.UTC)
+	if got := snapshotFileName("repo", 123, now); got != "repo-123-20261018-150405.json" {
+		t.Errorf("snapshotFileName() = %q", got)
+	}
+}
+
+func TestCheckSameRoot(t *testing.T) {
+	before, after := snapshotTestTrees()
+	if err := checkSameRoot(before, after); err != nil {
+		t.Errorf("unexpected error for snapshots of the same issue: %v", err)
+	}
+
+	other := &HierarchyNode{Repository: before.Repository, Number: before.Number + 1}
+	if err := checkSameRoot(before, other); err == nil || !strings.Contains(err.Error(), "different issues") {
+		t.Errorf("expected error for a different root, got %v", err)
+	}
+	moved := &HierarchyNode{Repository: "owner/elsewhere", Number: before.Number}
+	if err := checkSameRoot(before, moved); err == nil {
+		t.Error("expected error for a root in another repository")
+	}
+}
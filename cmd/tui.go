@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ghterm "github.com/cli/go-gh/v2/pkg/term"
+	"github.com/cli/go-gh/v2/pkg/text"
+	"golang.org/x/term"
+)
+
+// Key identifies a key press read from the terminal
+type Key int
+
+const (
+	KeyRune Key = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyShiftUp
+	KeyShiftDown
+	KeyEnter
+	KeyEscape
+	KeyBackspace
+	KeyTab
+	KeyCtrlC
+	KeyUnknown
+)
+
+// KeyEvent is a decoded key press
+type KeyEvent struct {
+	Key  Key
+	Rune rune
+}
+
+// decodeKey decodes the bytes of a single key press
+func decodeKey(b []byte) KeyEvent {
+	if len(b) == 0 {
+		return KeyEvent{Key: KeyUnknown}
+	}
+
+	switch string(b) {
+	case "\x1b[A", "\x1bOA":
+		return KeyEvent{Key: KeyUp}
+	case "\x1b[B", "\x1bOB":
+		return KeyEvent{Key: KeyDown}
+	case "\x1b[C", "\x1bOC":
+		return KeyEvent{Key: KeyRight}
+	case "\x1b[D", "\x1bOD":
+		return KeyEvent{Key: KeyLeft}
+	case "\x1b[1;2A", "\x1b[a":
+		return KeyEvent{Key: KeyShiftUp}
+	case "\x1b[1;2B", "\x1b[b":
+		return KeyEvent{Key: KeyShiftDown}
+	case "\x1b":
+		return KeyEvent{Key: KeyEscape}
+	}
+
+	switch b[0] {
+	case '\r', '\n':
+		return KeyEvent{Key: KeyEnter}
+	case 0x7f, 0x08:
+		return KeyEvent{Key: KeyBackspace}
+	case '\t':
+		return KeyEvent{Key: KeyTab}
+	case 0x03:
+		return KeyEvent{Key: KeyCtrlC}
+	case 0x1b:
+		return KeyEvent{Key: KeyUnknown}
+	}
+
+	r := []rune(string(b))
+	if len(r) == 0 || r[0] < 0x20 {
+		return KeyEvent{Key: KeyUnknown}
+	}
+	return KeyEvent{Key: KeyRune, Rune: r[0]}
+}
+
+// screen is a full-screen terminal session in raw mode
+type screen struct {
+	in       *os.File
+	out      io.Writer
+	state    *term.State
+	colorful bool
+}
+
+// openScreen switches the terminal to raw mode and the alternate screen
+func openScreen() (*screen, error) {
+	if !ghterm.IsTerminal(os.Stdin) || !ghterm.IsTerminal(os.Stdout) {
+		return nil, fmt.Errorf("an interactive terminal is required")
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+
+	s := &screen{
+		in:       os.Stdin,
+		out:      os.Stdout,
+		state:    state,
+		colorful: ghterm.FromEnv().IsColorEnabled(),
+	}
+	fmt.Fprint(s.out, "\x1b[?1049h\x1b[?25l")
+	return s, nil
+}
+
+// Close restores the terminal
+func (s *screen) Close() {
+	fmt.Fprint(s.out, "\x1b[?25h\x1b[?1049l")
+	term.Restore(int(s.in.Fd()), s.state)
+}
+
+// Size returns the terminal width and height
+func (s *screen) Size() (int, int) {
+	width, height, err := term.GetSize(int(s.in.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// ReadKey blocks until a key is pressed
+func (s *screen) ReadKey() (KeyEvent, error) {
+	buf := make([]byte, 16)
+	n, err := s.in.Read(buf)
+	if err != nil {
+		return KeyEvent{}, err
+	}
+	return decodeKey(buf[:n]), nil
+}
+
+// Draw replaces the screen contents with the given lines
+func (s *screen) Draw(lines []string) {
+	width, height := s.Size()
+
+	var output strings.Builder
+	output.WriteString("\x1b[H")
+	for i := 0; i < height; i++ {
+		if i < len(lines) {
+			output.WriteString(text.Truncate(width, lines[i]))
+		}
+		output.WriteString("\x1b[K")
+		if i < height-1 {
+			output.WriteString("\r\n")
+		}
+	}
+	fmt.Fprint(s.out, output.String())
+}
+
+// Style wraps s in an ANSI SGR sequence when colors are enabled
+func (s *screen) Style(code, str string) string {
+	if !s.colorful {
+		return str
+	}
+	return "\x1b[" + code + "m" + str + "\x1b[0m"
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestDecodeKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected KeyEvent
+	}{
+		{"arrow up", "\x1b[A", KeyEvent{Key: KeyUp}},
+		{"arrow down application mode", "\x1bOB", KeyEvent{Key: KeyDown}},
+		{"shift up", "\x1b[1;2A", KeyEvent{Key: KeyShiftUp}},
+		{"shift down", "\x1b[1;2B", KeyEvent{Key: KeyShiftDown}},
+		{"escape", "\x1b", KeyEvent{Key: KeyEscape}},
+		{"enter", "\r", KeyEvent{Key: KeyEnter}},
+		{"backspace", "\x7f", KeyEvent{Key: KeyBackspace}},
+		{"ctrl-c", "\x03", KeyEvent{Key: KeyCtrlC}},
+		{"letter", "a", KeyEvent{Key: KeyRune, Rune: 'a'}},
+		{"multibyte rune", "世", KeyEvent{Key: KeyRune, Rune: '世'}},
+		{"unknown escape sequence", "\x1b[15~", KeyEvent{Key: KeyUnknown}},
+		{"empty", "", KeyEvent{Key: KeyUnknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeKey([]byte(tt.input)); got != tt.expected {
+				t.Errorf("decodeKey(%q) = %+v, want %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// minWatchInterval keeps --watch from polling the API in a tight loop
+const minWatchInterval = 5 * time.Second
+
+// watchState is what --watch polls between refreshes to detect changes cheaply
+type watchState struct {
+	Fingerprint string
+	Complete    bool
+}
+
+// getWatchState fetches when a parent and its sub-issues were last updated,
+// the state of their blockers, and whether all sub-issues are closed
+func getWatchState(client *api.GraphQLClient, owner, repo string, number int) (*watchState, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					updatedAt
+					subIssuesSummary {
+						total
+						completed
+					}
+					subIssues(first: 100) {
+						nodes {
+							id
+							updatedAt
+							blockedBy(first: 20) {
+								nodes {
+									id
+									state
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue *struct {
+				UpdatedAt        string `json:"updatedAt"`
+				SubIssuesSummary struct {
+					Total     int `json:"total"`
+					Completed int `json:"completed"`
+				} `json:"subIssuesSummary"`
+				SubIssues struct {
+					Nodes []struct {
+						ID        string `json:"id"`
+						UpdatedAt string `json:"updatedAt"`
+						BlockedBy struct {
+							Nodes []struct {
+								ID    string `json:"id"`
+								State string `json:"state"`
+							} `json:"nodes"`
+						} `json:"blockedBy"`
+					} `json:"nodes"`
+				} `json:"subIssues"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	if err := client.Do(query, variables, &response); err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	issue := response.Repository.Issue
+	if issue == nil {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
+	}
+
+	summary := issue.SubIssuesSummary
+	parts := []string{issue.UpdatedAt, fmt.Sprintf("%d/%d", summary.Completed, summary.Total)}
+	for _, node := range issue.SubIssues.Nodes {
+		parts = append(parts, node.ID+"@"+node.UpdatedAt)
+		for _, blocker := range node.BlockedBy.Nodes {
+			parts = append(parts, blocker.ID+"="+blocker.State)
+		}
+	}
+	return &watchState{
+		Fingerprint: strings.Join(parts, " "),
+		Complete:    summary.Total > 0 && summary.Completed == summary.Total,
+	}, nil
+}
+
+// changedSubIssues returns the IDs of sub-issues that are new or differ from the previous result
+func changedSubIssues(previous, current *ListResult) map[string]bool {
+	if previous == nil {
+		return nil
+	}
+
+	signature := func(issue SubIssue) string {
+		data, _ := json.Marshal(issue)
+		return string(data)
+	}
+	before := make(map[string]string, len(previous.SubIssues))
+	for _, issue := range previous.SubIssues {
+		before[issue.ID] = signature(issue)
+	}
+
+	changed := make(map[string]bool)
+	for _, issue := range current.SubIssues {
+		if before[issue.ID] != signature(issue) {
+			changed[issue.ID] = true
+		}
+	}
+	return changed
+}
+
+// watchHeader is the status line shown above the list while watching
+func watchHeader(interval time.Duration, updated time.Time, changed int, complete bool) string {
+	parts := []string{fmt.Sprintf("Every %s", interval), "updated " + updated.Format("15:04:05")}
+	if changed > 0 {
+		parts = append(parts, fmt.Sprintf("%d changed", changed))
+	}
+	if complete {
+		parts = append(parts, "100% complete")
+	}
+	parts = append(parts, "Ctrl-C to stop")
+	return strings.Join(parts, " · ") + "\n"
+}
+
+// notifyComplete rings the terminal bell and, in a terminal, sends an OSC 9
+// desktop notification that terminals like iTerm2 and Windows Terminal show
+func notifyComplete(w io.Writer, tty bool, message string) {
+	fmt.Fprint(w, "\a")
+	if tty {
+		fmt.Fprintf(w, "\x1b]9;%s\x07", message)
+	}
+	fmt.Fprintf(w, "✓ %s\n", message)
+}
+
+// watchList refreshes the list until interrupted. Between refreshes only a
+// small query is made; the list is fetched again when it reports a change.
+func watchList(cmd *cobra.Command, client *api.GraphQLClient, parentRef *IssueReference, columns []string, filters []FieldAssignment) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	tty := term.FromEnv().IsTerminalOutput()
+	out := cmd.OutOrStdout()
+	var previous *ListResult
+	var output, fingerprint string
+	changed, complete := 0, false
+
+	for first := true; ; first = false {
+		state, err := getWatchState(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+		if err != nil && first {
+			return err
+		}
+
+		// Project field values do not change when issues were updated, so they are always fetched
+		// again. After a change, the list is fetched once more to clear the highlighted rows.
+		if err == nil && (first || state.Fingerprint != fingerprint || changed > 0 || listProjectFlag != "") {
+			var rendered string
+			var result *ListResult
+			rendered, result, err = renderList(client, parentRef, columns, filters, previous)
+			if err != nil && first {
+				return err
+			}
+			if err == nil {
+				if !tty && rendered != output {
+					fmt.Fprint(out, rendered)
+				}
+				output, fingerprint = rendered, state.Fingerprint
+				if result != nil {
+					previous, changed = result, len(result.Changed)
+				}
+			}
+		}
+
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "! Refresh failed: %v\n", err)
+		} else {
+			if listNotifyFlag && state.Complete && !complete && !first {
+				notifyComplete(cmd.ErrOrStderr(), tty, fmt.Sprintf("All sub-issues of #%d are closed", parentRef.Number))
+			}
+			complete = state.Complete
+		}
+
+		if tty && err == nil {
+			// Redraw in place
+			fmt.Fprint(out, "\x1b[H\x1b[2J"+watchHeader(listWatchFlag, time.Now(), changed, complete)+output)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(listWatchFlag):
+		}
+	}
+}
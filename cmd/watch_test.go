@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChangedSubIssues(t *testing.T) {
+	previous := &ListResult{SubIssues: []SubIssue{
+		{ID: "I_1", Number: 1, Title: "Design", State: "open"},
+		{ID: "I_2", Number: 2, Title: "Build", State: "open", Assignees: []string{"alice"}},
+		{ID: "I_3", Number: 3, Title: "Test", State: "open"},
+	}}
+	current := &ListResult{SubIssues: []SubIssue{
+		{ID: "I_1", Number: 1, Title: "Design", State: "closed"},
+		{ID: "I_2", Number: 2, Title: "Build", State: "open", Assignees: []string{"alice"}},
+		{ID: "I_3", Number: 3, Title: "Test", State: "open", ProjectFields: map[string]string{"Status": "Done"}},
+		{ID: "I_4", Number: 4, Title: "Docs", State: "open"},
+	}}
+
+	changed := changedSubIssues(previous, current)
+	if len(changed) != 3 || !changed["I_1"] || !changed["I_3"] || !changed["I_4"] {
+		t.Errorf("changedSubIssues() = %v, want I_1, I_3 and I_4", changed)
+	}
+	if changed := changedSubIssues(nil, current); len(changed) != 0 {
+		t.Errorf("expected no changes on the first refresh, got %v", changed)
+	}
+}
+
+func TestFormatTTYHighlightsChangedRows(t *testing.T) {
+	result := &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Epic"},
+		SubIssues: []SubIssue{
+			{ID: "I_2", Number: 2, Title: "Changed", State: "open"},
+			{ID: "I_3", Number: 3, Title: "Same", State: "open"},
+		},
+		Total:     2,
+		OpenCount: 2,
+		Changed:   map[string]bool{"I_2": true},
+	}
+
+	output := formatTTY(result, 80, &colorScheme{enabled: true})
+	if !strings.Contains(output, "\x1b[7mChanged") {
+		t.Errorf("expected changed row to be highlighted:\n%q", output)
+	}
+	if strings.Contains(output, "\x1b[7mSame") {
+		t.Errorf("expected unchanged row not to be highlighted:\n%q", output)
+	}
+}
+
+func TestWatchHeader(t *testing.T) {
+	updated := time.Date(2026, 10, 18, 15, 4, 5, 0, time.Local)
+
+	if got := watchHeader(30*time.Second, updated, 0, false); got != "Every 30s · updated 15:04:05 · Ctrl-C to stop\n" {
+		t.Errorf("watchHeader() = %q", got)
+	}
+	want := "Every 1m0s · updated 15:04:05 · 2 changed · 100% complete · Ctrl-C to stop\n"
+	if got := watchHeader(time.Minute, updated, 2, true); got != want {
+		t.Errorf("watchHeader() = %q, want %q", got, want)
+	}
+}
+
+func TestNotifyComplete(t *testing.T) {
+	var out bytes.Buffer
+	notifyComplete(&out, false, "All sub-issues of #1 are closed")
+	if out.String() != "\a✓ All sub-issues of #1 are closed\n" {
+		t.Errorf("notifyComplete() = %q", out.String())
+	}
+
+	out.Reset()
+	notifyComplete(&out, true, "done")
+	if out.String() != "\a\x1b]9;done\x07✓ done\n" {
+		t.Errorf("notifyComplete() in a terminal = %q", out.String())
+	}
+}
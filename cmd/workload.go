@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workloadRepoFlag      string
+	workloadThresholdFlag int
+	workloadJSONFlag      bool
+)
+
+var workloadCmd = &cobra.Command{
+	Use:   "workload <issue>...",
+	Short: "Show open sub-issues per assignee across one or more parents",
+	Long: `Count the open descendants of one or more parent issues per assignee.
+
+Issues with several assignees count for each of them, and issues below more
+than one of the parents are counted once. Open issues without an assignee are
+listed as unassigned. With --threshold, assignees with more open issues than
+the threshold are flagged as overloaded.
+
+Examples:
+  # Workload across two epics
+  gh sub-issues workload 123 456
+
+  # Flag anyone with more than 5 open issues
+  gh sub-issues workload 123 --threshold 5
+
+  # JSON output
+  gh sub-issues workload 123 --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWorkload,
+}
+
+func init() {
+	// Add command to root
+	rootCmd.AddCommand(workloadCmd)
+
+	// Add flags
+	workloadCmd.Flags().StringVarP(&workloadRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	workloadCmd.Flags().IntVar(&workloadThresholdFlag, "threshold", 0, "Flag assignees with more open issues than this (0 to disable)")
+	workloadCmd.Flags().BoolVar(&workloadJSONFlag, "json", false, "Output in JSON format")
+}
+
+// AssigneeLoad is the open work of one assignee
+type AssigneeLoad struct {
+	Assignee   string      `json:"assignee"`
+	Open       int         `json:"open"`
+	Overloaded bool        `json:"overloaded"`
+	Issues     []IssueLink `json:"issues"`
+}
+
+// WorkloadReport is the open work below a set of parents, by assignee
+type WorkloadReport struct {
+	Parents    []IssueLink    `json:"parents"`
+	Threshold  int            `json:"threshold,omitempty"`
+	Open       int            `json:"open"`
+	Assignees  []AssigneeLoad `json:"assignees"`
+	Unassigned []IssueLink    `json:"unassigned"`
+}
+
+// buildWorkload counts the open descendants of the roots per assignee, busiest first
+func buildWorkload(roots []*HierarchyNode, threshold int) *WorkloadReport {
+	report := &WorkloadReport{
+		Threshold:  threshold,
+		Assignees:  []AssigneeLoad{},
+		Unassigned: []IssueLink{},
+	}
+	loads := make(map[string]*AssigneeLoad)
+	seen := make(map[string]bool)
+
+	for _, root := range roots {
+		report.Parents = append(report.Parents, nodeLink(root))
+		walkHierarchy(root, func(node, parent *HierarchyNode, depth int) {
+			if node.State != "open" || seen[node.ID] {
+				return
+			}
+			seen[node.ID] = true
+			report.Open++
+
+			link := nodeLink(node)
+			if len(node.Assignees) == 0 {
+				report.Unassigned = append(report.Unassigned, link)
+				return
+			}
+			for _, login := range node.Assignees {
+				load, ok := loads[login]
+				if !ok {
+					load = &AssigneeLoad{Assignee: login}
+					loads[login] = load
+				}
+				load.Open++
+				load.Issues = append(load.Issues, link)
+			}
+		})
+	}
+
+	for _, load := range loads {
+		load.Overloaded = threshold > 0 && load.Open > threshold
+		report.Assignees = append(report.Assignees, *load)
+	}
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		return a.Assignee < b.Assignee
+	})
+	return report
+}
+
+// issueRefs joins issue references relative to a repository
+func issueRefs(issues []IssueLink, repository string) string {
+	refs := make([]string, len(issues))
+	for i, issue := range issues {
+		refs[i] = linkRef(issue, repository)
+	}
+	return strings.Join(refs, ", ")
+}
+
+// formatWorkloadTTY formats the workload as a table that fits the given width
+func formatWorkloadTTY(report *WorkloadReport, width int, cs *colorScheme) string {
+	var output strings.Builder
+	repository := report.Parents[0].Repository
+	output.WriteString(fmt.Sprintf("\n%s %s (%d open)\n\n", cs.Bold("Workload across"), issueRefs(report.Parents, repository), report.Open))
+	if report.Open == 0 {
+		output.WriteString("No open sub-issues found.\n")
+		return output.String()
+	}
+
+	// Unassigned work gets a last row when there is any
+	n := len(report.Assignees)
+	rows := n
+	if len(report.Unassigned) > 0 {
+		rows++
+	}
+	names := ttyColumn{header: "ASSIGNEE", values: make([]string, rows)}
+	counts := ttyColumn{header: "OPEN", values: make([]string, rows)}
+	statuses := ttyColumn{header: "STATUS", values: make([]string, rows), limit: len("overloaded")}
+	issues := ttyColumn{header: "ISSUES", values: make([]string, rows)}
+	for i, load := range report.Assignees {
+		names.values[i] = "@" + load.Assignee
+		counts.values[i] = fmt.Sprintf("%d", load.Open)
+		if load.Overloaded {
+			statuses.values[i] = "overloaded"
+		}
+		issues.values[i] = issueRefs(load.Issues, repository)
+	}
+	if rows > n {
+		names.values[n] = "unassigned"
+		counts.values[n] = fmt.Sprintf("%d", len(report.Unassigned))
+		issues.values[n] = issueRefs(report.Unassigned, repository)
+	}
+
+	names.style = func(i int, s string) string {
+		if i == n {
+			return cs.Gray(s)
+		}
+		return s
+	}
+	counts.style = func(i int, s string) string {
+		if i < n && report.Assignees[i].Overloaded {
+			return cs.Yellow(s)
+		}
+		return s
+	}
+	statuses.style = func(i int, s string) string { return cs.Yellow(s) }
+	issues.style = func(i int, s string) string { return cs.Gray(s) }
+
+	output.WriteString(renderTable([]*ttyColumn{&names, &counts, &statuses, &issues}, &issues, width, cs))
+	return output.String()
+}
+
+// formatWorkloadPlain formats the workload as tab-separated lines, with
+// unassigned work on a line without an assignee
+func formatWorkloadPlain(report *WorkloadReport) string {
+	var output strings.Builder
+	repository := report.Parents[0].Repository
+	for _, load := range report.Assignees {
+		status := ""
+		if load.Overloaded {
+			status = "overloaded"
+		}
+		output.WriteString(fmt.Sprintf("%s\t%d\t%s\t%s\n", load.Assignee, load.Open, status, issueRefs(load.Issues, repository)))
+	}
+	if len(report.Unassigned) > 0 {
+		output.WriteString(fmt.Sprintf("\t%d\t\t%s\n", len(report.Unassigned), issueRefs(report.Unassigned, repository)))
+	}
+	return output.String()
+}
+
+// runWorkload is the main command logic for workload
+func runWorkload(cmd *cobra.Command, args []string) error {
+	if workloadThresholdFlag < 0 {
+		return fmt.Errorf("invalid threshold: %d", workloadThresholdFlag)
+	}
+
+	defaultOwner, defaultRepo, err := resolveRepo(workloadRepoFlag)
+	if err != nil {
+		return err
+	}
+
+	var refs []*IssueReference
+	for _, arg := range args {
+		ref, err := parseIssueReference(arg, defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid issue %s: %w", arg, err)
+		}
+		refs = append(refs, ref)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	var roots []*HierarchyNode
+	for _, ref := range refs {
+		fmt.Fprintf(cmd.OutOrStderr(), "Fetching hierarchy of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+		root, err := fetchHierarchy(client, ref.Owner, ref.Repo, ref.Number, 0)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, root)
+	}
+
+	report := buildWorkload(roots, workloadThresholdFlag)
+
+	if workloadJSONFlag {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	if t := term.FromEnv(); t.IsTerminalOutput() {
+		width, _, err := t.Size()
+		if err != nil || width <= 0 {
+			width = 80
+		}
+		fmt.Fprint(cmd.OutOrStdout(), formatWorkloadTTY(report, width, newColorScheme(t)))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), formatWorkloadPlain(report))
+	return nil
+}
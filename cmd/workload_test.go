@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// workloadTestRoots returns two parents that share one sub-issue
+func workloadTestRoots() []*HierarchyNode {
+	shared := &HierarchyNode{ID: "I_5", Repository: "owner/repo", Number: 5, State: "open", Assignees: []string{"bob"}}
+	return []*HierarchyNode{
+		{ID: "I_1", Repository: "owner/repo", Number: 1, State: "open", SubIssues: []*HierarchyNode{
+			{ID: "I_2", Repository: "owner/repo", Number: 2, State: "open", Assignees: []string{"alice"}, SubIssues: []*HierarchyNode{
+				{ID: "I_3", Repository: "owner/repo", Number: 3, State: "open", Assignees: []string{"alice", "bob"}},
+				{ID: "I_4", Repository: "owner/repo", Number: 4, State: "closed", Assignees: []string{"carol"}},
+			}},
+			shared,
+		}},
+		{ID: "I_10", Repository: "owner/repo", Number: 10, State: "open", SubIssues: []*HierarchyNode{
+			shared,
+			{ID: "I_11", Repository: "owner/other", Number: 11, State: "open"},
+			{ID: "I_12", Repository: "owner/repo", Number: 12, State: "open", Assignees: []string{"alice"}},
+		}},
+	}
+}
+
+func TestBuildWorkload(t *testing.T) {
+	report := buildWorkload(workloadTestRoots(), 2)
+
+	if report.Open != 5 {
+		t.Errorf("open = %d, want 5 (closed and shared issues are counted once at most)", report.Open)
+	}
+	if len(report.Parents) != 2 {
+		t.Errorf("got %d parents, want 2", len(report.Parents))
+	}
+
+	var got []string
+	for _, load := range report.Assignees {
+		got = append(got, load.Assignee+":"+issueRefs(load.Issues, "owner/repo"))
+		if load.Overloaded != (load.Assignee == "alice") {
+			t.Errorf("%s overloaded = %v", load.Assignee, load.Overloaded)
+		}
+	}
+	want := "alice:#2, #3, #12 bob:#3, #5"
+	if strings.Join(got, " ") != want {
+		t.Errorf("assignees = %s, want %s", strings.Join(got, " "), want)
+	}
+	if refs := issueRefs(report.Unassigned, "owner/repo"); refs != "owner/other#11" {
+		t.Errorf("unassigned = %s", refs)
+	}
+
+	if report := buildWorkload(workloadTestRoots(), 0); report.Assignees[0].Overloaded {
+		t.Error("expected no overloaded assignees without a threshold")
+	}
+}
+
+func TestFormatWorkloadTTY(t *testing.T) {
+	report := buildWorkload(workloadTestRoots(), 2)
+
+	want := "\nWorkload across #1, #10 (5 open)\n\n" +
+		"ASSIGNEE    OPEN  STATUS      ISSUES\n" +
+		"@alice      3     overloaded  #2, #3, #12\n" +
+		"@bob        2                 #3, #5\n" +
+		"unassigned  1                 owner/other#11\n"
+	if got := formatWorkloadTTY(report, 80, &colorScheme{}); got != want {
+		t.Errorf("formatWorkloadTTY() =\n%s\nwant:\n%s", got, want)
+	}
+
+	empty := buildWorkload([]*HierarchyNode{{Repository: "owner/repo", Number: 1}}, 0)
+	if got := formatWorkloadTTY(empty, 80, &colorScheme{}); !strings.HasSuffix(got, "No open sub-issues found.\n") {
+		t.Errorf("unexpected output for empty workload:\n%s", got)
+	}
+}
+
+func TestFormatWorkloadPlain(t *testing.T) {
+	report := buildWorkload(workloadTestRoots(), 2)
+
+	want := "alice\t3\toverloaded\t#2, #3, #12\n" +
+		"bob\t2\t\t#3, #5\n" +
+		"\t1\t\towner/other#11\n"
+	if got := formatWorkloadPlain(report); got != want {
+		t.Errorf("formatWorkloadPlain() = %q, want %q", got, want)
+	}
+}